@@ -7,7 +7,6 @@ import (
 )
 
 type ICharacter interface {
-
 	GetHP() int
 	GetMaxHP() int
 	GetName() string
@@ -16,13 +15,17 @@ type ICharacter interface {
 	GetIntelligence() int
 	GetAttack() float32
 	GetDefense() float32
-
+	GetCritChance() float32
+	GetCritDamage() float32
+	GetEvasion() float32
+	GetDodgeChance() float32
+	GetLifesteal() float32
+	GetMagicAmp() float32
 
 	TakeDamage(damage int)
 	Heal(amount float32)
 	Hit() string
 	Block() string
-	Attack(target ICharacter, attackPart, blockPart string)
 
 	SetAttack(attack float32)
 	SetDefense(defense float32)
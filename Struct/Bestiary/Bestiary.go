@@ -0,0 +1,429 @@
+package Bestiary
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"MyGame/Struct/Character"
+	"MyGame/Struct/Item"
+)
+
+type AIProfile string
+
+const (
+	AIAggressive AIProfile = "aggressive"
+	AIDefensive  AIProfile = "defensive"
+	AIBalanced   AIProfile = "balanced"
+)
+
+var aiProfileNames = map[AIProfile]string{
+	AIAggressive: "Агрессивный",
+	AIDefensive:  "Осторожный",
+	AIBalanced:   "Уравновешенный",
+}
+
+func (p AIProfile) DisplayName() string {
+	if name, ok := aiProfileNames[p]; ok {
+		return name
+	}
+	return "Неизвестно"
+}
+
+//go:embed bestiary.json
+var embeddedBestiary []byte
+
+type EquipmentEntry struct {
+	TemplateID int         `json:"template_id"`
+	Rarity     Item.Rarity `json:"rarity"`
+	Level      int         `json:"level"`
+}
+
+type LootEntry struct {
+	TemplateID int `json:"template_id"`
+	Weight     int `json:"weight"`
+}
+
+type Enemy struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Description  string           `json:"description"`
+	Art          []string         `json:"art"`
+	Level        int              `json:"level"`
+	HP           int              `json:"hp"`
+	Strength     int              `json:"strength"`
+	Agility      int              `json:"agility"`
+	Intelligence int              `json:"intelligence"`
+	AI           AIProfile        `json:"ai"`
+	Weight       int              `json:"weight"`
+	AreaAttack   bool             `json:"area_attack"`
+	Companion    bool             `json:"companion"`
+	Equipment    []EquipmentEntry `json:"equipment"`
+	Loot         []LootEntry      `json:"loot"`
+	Gold         int              `json:"gold"`
+}
+
+type Encounter struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Enemies []string `json:"enemies"`
+	Allies  []string `json:"allies"`
+	Weight  int      `json:"weight"`
+}
+
+type bestiaryFile struct {
+	Enemies    []*Enemy     `json:"enemies"`
+	Encounters []*Encounter `json:"encounters"`
+}
+
+type Bestiary struct {
+	enemies        map[string]*Enemy
+	order          []string
+	encounters     map[string]*Encounter
+	encounterOrder []string
+}
+
+func New() *Bestiary {
+	return &Bestiary{
+		enemies:    make(map[string]*Enemy),
+		encounters: make(map[string]*Encounter),
+	}
+}
+
+func Load() (*Bestiary, error) {
+	b := New()
+	if err := b.LoadData(embeddedBestiary); err != nil {
+		return nil, fmt.Errorf("встроенный бестиарий: %w", err)
+	}
+	if err := b.LoadDir(UserDir()); err != nil {
+		return b, err
+	}
+	return b, nil
+}
+
+func UserDir() string {
+	if dir := os.Getenv("BESTIARY_DIR"); dir != "" {
+		return dir
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "ItsHard", "bestiary")
+}
+
+func (b *Bestiary) LoadData(data []byte) error {
+	var file bestiaryFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("ошибка разбора бестиария: %w", err)
+	}
+	staged := b.clone()
+	for _, enemy := range file.Enemies {
+		if err := enemy.validate(); err != nil {
+			return err
+		}
+		staged.put(enemy)
+	}
+	for _, encounter := range file.Encounters {
+		if err := staged.validateEncounter(encounter); err != nil {
+			return err
+		}
+		staged.putEncounter(encounter)
+	}
+	*b = *staged
+	return nil
+}
+
+func (b *Bestiary) clone() *Bestiary {
+	c := New()
+	for id, enemy := range b.enemies {
+		c.enemies[id] = enemy
+	}
+	for id, encounter := range b.encounters {
+		c.encounters[id] = encounter
+	}
+	c.order = append([]string(nil), b.order...)
+	c.encounterOrder = append([]string(nil), b.encounterOrder...)
+	return c
+}
+
+func (b *Bestiary) LoadDir(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("чтение каталога бестиария %s: %w", dir, err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var errs []string
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		if err := b.LoadData(data); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("ошибки пользовательского бестиария: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (b *Bestiary) put(enemy *Enemy) {
+	if _, exists := b.enemies[enemy.ID]; !exists {
+		b.order = append(b.order, enemy.ID)
+	}
+	b.enemies[enemy.ID] = enemy
+}
+
+func (b *Bestiary) putEncounter(encounter *Encounter) {
+	if _, exists := b.encounters[encounter.ID]; !exists {
+		b.encounterOrder = append(b.encounterOrder, encounter.ID)
+	}
+	b.encounters[encounter.ID] = encounter
+}
+
+func (b *Bestiary) validateEncounter(encounter *Encounter) error {
+	if encounter == nil {
+		return fmt.Errorf("пустая запись встречи")
+	}
+	if strings.TrimSpace(encounter.ID) == "" {
+		return fmt.Errorf("у встречи '%s' не задан id", encounter.Name)
+	}
+	if len(encounter.Enemies) == 0 {
+		return fmt.Errorf("во встрече '%s' нет противников", encounter.ID)
+	}
+	for _, id := range append(append([]string{}, encounter.Enemies...), encounter.Allies...) {
+		if b.enemies[id] == nil {
+			return fmt.Errorf("во встрече '%s' неизвестный участник '%s'", encounter.ID, id)
+		}
+	}
+	if encounter.Name == "" {
+		encounter.Name = b.enemies[encounter.Enemies[0]].Name
+	}
+	return nil
+}
+
+func (b *Bestiary) Get(id string) *Enemy {
+	if b == nil {
+		return nil
+	}
+	return b.enemies[id]
+}
+
+func (b *Bestiary) All() []*Enemy {
+	if b == nil {
+		return nil
+	}
+	out := make([]*Enemy, 0, len(b.order))
+	for _, id := range b.order {
+		out = append(out, b.enemies[id])
+	}
+	return out
+}
+
+func (b *Bestiary) Foes() []*Enemy {
+	out := make([]*Enemy, 0)
+	for _, e := range b.All() {
+		if !e.Companion {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (b *Bestiary) Len() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.order)
+}
+
+func (b *Bestiary) Encounters() []*Encounter {
+	if b == nil {
+		return nil
+	}
+	out := make([]*Encounter, 0, len(b.encounterOrder)+len(b.order))
+	for _, id := range b.encounterOrder {
+		out = append(out, b.encounters[id])
+	}
+	for _, e := range b.Foes() {
+		out = append(out, &Encounter{ID: e.ID, Name: e.Name, Enemies: []string{e.ID}, Weight: e.Weight})
+	}
+	return out
+}
+
+func (b *Bestiary) Encounter(id string) *Encounter {
+	if b == nil {
+		return nil
+	}
+	if encounter := b.encounters[id]; encounter != nil {
+		return encounter
+	}
+	if e := b.enemies[id]; e != nil {
+		return &Encounter{ID: e.ID, Name: e.Name, Enemies: []string{e.ID}, Weight: e.Weight}
+	}
+	return nil
+}
+
+func (b *Bestiary) RollEncounter(rng *rand.Rand) *Encounter {
+	encounters := b.Encounters()
+	if len(encounters) == 0 {
+		return nil
+	}
+	total := 0
+	for _, e := range encounters {
+		total += max(e.Weight, 0)
+	}
+	if total == 0 {
+		return encounters[rng.Intn(len(encounters))]
+	}
+	roll := rng.Intn(total)
+	for _, e := range encounters {
+		roll -= max(e.Weight, 0)
+		if roll < 0 {
+			return e
+		}
+	}
+	return encounters[len(encounters)-1]
+}
+
+func (e *Enemy) validate() error {
+	if e == nil {
+		return fmt.Errorf("пустая запись бестиария")
+	}
+	if strings.TrimSpace(e.ID) == "" {
+		return fmt.Errorf("у противника '%s' не задан id", e.Name)
+	}
+	if strings.TrimSpace(e.Name) == "" {
+		return fmt.Errorf("у противника '%s' не задано имя", e.ID)
+	}
+	if e.HP <= 0 {
+		return fmt.Errorf("у противника '%s' HP должно быть положительным", e.ID)
+	}
+	if e.Strength < Character.MinStrength || e.Agility < Character.MinAgility || e.Intelligence < Character.MinIntelligence {
+		return fmt.Errorf("у противника '%s' сила, ловкость и интеллект должны быть не меньше 1 (сейчас %d/%d/%d)",
+			e.ID, e.Strength, e.Agility, e.Intelligence)
+	}
+	if e.Level < 1 {
+		e.Level = 1
+	}
+	switch e.AI {
+	case AIAggressive, AIDefensive, AIBalanced:
+	case "":
+		e.AI = AIBalanced
+	default:
+		return fmt.Errorf("у противника '%s' неизвестный профиль ИИ '%s'", e.ID, e.AI)
+	}
+	for _, entry := range e.Equipment {
+		item, err := Item.CreateItem(entry.TemplateID, entry.Rarity, max(entry.Level, 1))
+		if err != nil {
+			return fmt.Errorf("снаряжение противника '%s': %w", e.ID, err)
+		}
+		if item.Template.Slot == Item.SlotNone {
+			return fmt.Errorf("снаряжение противника '%s': предмет '%s' нельзя надеть", e.ID, item.Template.Name)
+		}
+	}
+	for _, entry := range e.Loot {
+		if entry.Weight < 0 {
+			return fmt.Errorf("у противника '%s' отрицательный вес добычи", e.ID)
+		}
+		if _, err := Item.CreateItem(entry.TemplateID, Item.Common, 1); err != nil {
+			return fmt.Errorf("добыча противника '%s': %w", e.ID, err)
+		}
+	}
+	if e.Gold < 0 {
+		return fmt.Errorf("у противника '%s' отрицательное количество золота", e.ID)
+	}
+	return nil
+}
+
+func (e *Enemy) Scaled(hp, strength, agility float64) *Enemy {
+	scaled := *e
+	scaled.HP = max(1, int(float64(e.HP)*hp))
+	scaled.Strength = max(Character.MinStrength, int(float64(e.Strength)*strength))
+	scaled.Agility = max(Character.MinAgility, int(float64(e.Agility)*agility))
+	return &scaled
+}
+
+func (e *Enemy) XPReward() int {
+	return max(e.Level, 1)*20 + e.HP/4 + e.Strength*2 + e.Agility
+}
+
+func (e *Enemy) RarityShift(bonus int) int {
+	return bonus + (max(e.Level, 1)-1)/2
+}
+
+func (e *Enemy) RollLoot(rng *rand.Rand, chance float64, rarityBonus int) (*Item.Item, error) {
+	total := 0
+	for _, entry := range e.Loot {
+		total += entry.Weight
+	}
+	if total == 0 || rng.Float64() >= chance {
+		return nil, nil
+	}
+	roll := rng.Intn(total)
+	for _, entry := range e.Loot {
+		if roll < entry.Weight {
+			item, err := Item.GenerateItem(rng, entry.TemplateID, Item.RollRarity(rng, e.RarityShift(rarityBonus)), max(e.Level, 1))
+			if err != nil {
+				return nil, fmt.Errorf("добыча противника '%s': %w", e.ID, err)
+			}
+			return item, nil
+		}
+		roll -= entry.Weight
+	}
+	return nil, nil
+}
+
+func (e *Enemy) RollGold(rng *rand.Rand) int {
+	gold := e.Gold
+	if gold == 0 {
+		gold = max(e.Level, 1) * 8
+	}
+	return gold/2 + rng.Intn(gold+1)
+}
+
+func (e *Enemy) NewCharacter() (*Character.Character, error) {
+	char, err := Character.New(e.Name, e.HP, e.Strength, e.Agility, e.Intelligence)
+	if err != nil {
+		return nil, fmt.Errorf("противник '%s': %w", e.ID, err)
+	}
+	char.Description = e.Description
+	char.Level = max(e.Level, 1)
+
+	for _, entry := range e.Equipment {
+		level := max(entry.Level, 1)
+		item, err := Item.CreateItem(entry.TemplateID, entry.Rarity, level)
+		if err != nil {
+			return nil, fmt.Errorf("противник '%s': %w", e.ID, err)
+		}
+		if err := char.Equipment.Equip(item); err != nil {
+			return nil, fmt.Errorf("противник '%s': %w", e.ID, err)
+		}
+	}
+
+	char.CalculateStats()
+	return char, nil
+}
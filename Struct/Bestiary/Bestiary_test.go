@@ -0,0 +1,68 @@
+package Bestiary
+
+import "testing"
+
+func TestLoadDataIsAtomic(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"битый JSON", `{"enemies": [`},
+		{"противник без HP", `{"enemies": [
+			{"id": "bandit", "name": "Бандит", "hp": 30, "strength": 5, "agility": 5, "intelligence": 2},
+			{"id": "ghost", "name": "Призрак", "hp": 0}
+		]}`},
+		{"противник без характеристик", `{"enemies": [
+			{"id": "bandit", "name": "Бандит", "hp": 30, "strength": 5, "agility": 5, "intelligence": 2},
+			{"id": "g", "name": "G", "hp": 10}
+		]}`},
+		{"нулевой интеллект", `{"enemies": [
+			{"id": "bandit", "name": "Бандит", "hp": 30, "strength": 5, "agility": 5, "intelligence": 0}
+		]}`},
+		{"неизвестное снаряжение", `{"enemies": [
+			{"id": "bandit", "name": "Бандит", "hp": 30, "strength": 5, "agility": 5, "intelligence": 2},
+			{"id": "knight", "name": "Рыцарь", "hp": 50, "strength": 9, "agility": 4, "intelligence": 2, "equipment": [{"template_id": 99999}]}
+		]}`},
+		{"неизвестная добыча", `{"enemies": [
+			{"id": "bandit", "name": "Бандит", "hp": 30, "strength": 5, "agility": 5, "intelligence": 2, "loot": [{"template_id": 99999, "weight": 1}]}
+		]}`},
+		{"встреча с неизвестным участником", `{"enemies": [
+			{"id": "bandit", "name": "Бандит", "hp": 30, "strength": 5, "agility": 5, "intelligence": 2}
+		], "encounters": [{"id": "ambush", "enemies": ["bandit", "nobody"]}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			enemies, encounters := b.Len(), len(b.Encounters())
+			if err := b.LoadData([]byte(tt.data)); err == nil {
+				t.Fatal("ожидалась ошибка загрузки")
+			}
+			if b.Len() != enemies || len(b.Encounters()) != encounters || b.Get("bandit") != nil {
+				t.Errorf("бестиарий изменился после ошибки: %d→%d противников, %d→%d встреч",
+					enemies, b.Len(), encounters, len(b.Encounters()))
+			}
+		})
+	}
+}
+
+func TestLoadDataAddsValidFile(t *testing.T) {
+	b := New()
+	data := `{"enemies": [
+		{"id": "bandit", "name": "Бандит", "hp": 30, "strength": 5, "agility": 5, "intelligence": 2}
+	], "encounters": [{"id": "gang", "enemies": ["bandit", "bandit"]}]}`
+	if err := b.LoadData([]byte(data)); err != nil {
+		t.Fatalf("LoadData: %v", err)
+	}
+	if b.Get("bandit") == nil || b.Encounter("gang") == nil {
+		t.Fatal("записи не загрузились")
+	}
+	if got := b.Encounter("gang").Name; got != "Бандит" {
+		t.Errorf("имя встречи %q, ожидалось имя первого противника", got)
+	}
+	if got := b.Get("bandit").AI; got != AIBalanced {
+		t.Errorf("профиль ИИ по умолчанию %q", got)
+	}
+}
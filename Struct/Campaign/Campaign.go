@@ -0,0 +1,173 @@
+package Campaign
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:embed campaign.json
+var embeddedCampaign []byte
+
+type Scene struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Text     []string `json:"text"`
+	Battle   string   `json:"battle"`
+	Requires []string `json:"requires"`
+	Sets     []string `json:"sets"`
+	Gold     int      `json:"gold"`
+}
+
+type Chapter struct {
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	Scenes []*Scene `json:"scenes"`
+}
+
+type Campaign struct {
+	Chapters []*Chapter `json:"chapters"`
+}
+
+type Progress struct {
+	Chapter int
+	Scene   int
+	Flags   map[string]bool
+}
+
+func Load(hasBattle func(id string) bool) (*Campaign, error) {
+	c := &Campaign{}
+	if err := json.Unmarshal(embeddedCampaign, c); err != nil {
+		return &Campaign{}, fmt.Errorf("ошибка разбора кампании: %w", err)
+	}
+	if err := c.validate(hasBattle); err != nil {
+		return &Campaign{}, err
+	}
+	return c, nil
+}
+
+func (c *Campaign) validate(hasBattle func(id string) bool) error {
+	if len(c.Chapters) == 0 {
+		return fmt.Errorf("в кампании нет глав")
+	}
+	seen := make(map[string]bool)
+	for _, chapter := range c.Chapters {
+		if chapter == nil || strings.TrimSpace(chapter.ID) == "" {
+			return fmt.Errorf("у главы кампании не задан id")
+		}
+		if len(chapter.Scenes) == 0 {
+			return fmt.Errorf("в главе '%s' нет сцен", chapter.ID)
+		}
+		for _, scene := range chapter.Scenes {
+			if scene == nil || strings.TrimSpace(scene.ID) == "" {
+				return fmt.Errorf("у сцены в главе '%s' не задан id", chapter.ID)
+			}
+			key := chapter.ID + "/" + scene.ID
+			if seen[key] {
+				return fmt.Errorf("сцена '%s' повторяется", key)
+			}
+			seen[key] = true
+			if scene.Battle != "" && hasBattle != nil && !hasBattle(scene.Battle) {
+				return fmt.Errorf("сцена '%s': неизвестный бой '%s'", key, scene.Battle)
+			}
+			if scene.Gold < 0 {
+				return fmt.Errorf("сцена '%s': отрицательная награда", key)
+			}
+		}
+	}
+	return nil
+}
+
+func NewProgress() *Progress {
+	return &Progress{Flags: make(map[string]bool)}
+}
+
+func Restore(chapter, scene int, flags []string) *Progress {
+	p := NewProgress()
+	p.Chapter, p.Scene = max(chapter, 0), max(scene, 0)
+	for _, flag := range flags {
+		p.Flags[flag] = true
+	}
+	return p
+}
+
+func (p *Progress) Has(flag string) bool {
+	return p.Flags[flag]
+}
+
+func (p *Progress) FlagList() []string {
+	out := make([]string, 0, len(p.Flags))
+	for flag, ok := range p.Flags {
+		if ok {
+			out = append(out, flag)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (p *Progress) Started() bool {
+	return p.Chapter > 0 || p.Scene > 0 || len(p.Flags) > 0
+}
+
+func (c *Campaign) available(p *Progress, scene *Scene) bool {
+	for _, flag := range scene.Requires {
+		if !p.Has(flag) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Campaign) normalize(p *Progress) {
+	for p.Chapter < len(c.Chapters) {
+		chapter := c.Chapters[p.Chapter]
+		if p.Scene >= len(chapter.Scenes) {
+			p.Chapter++
+			p.Scene = 0
+			continue
+		}
+		if !c.available(p, chapter.Scenes[p.Scene]) {
+			p.Scene++
+			continue
+		}
+		return
+	}
+}
+
+func (c *Campaign) Current(p *Progress) (*Chapter, *Scene) {
+	c.normalize(p)
+	if c.Finished(p) {
+		return nil, nil
+	}
+	chapter := c.Chapters[p.Chapter]
+	return chapter, chapter.Scenes[p.Scene]
+}
+
+func (c *Campaign) Complete(p *Progress) *Scene {
+	_, scene := c.Current(p)
+	if scene == nil {
+		return nil
+	}
+	for _, flag := range scene.Sets {
+		p.Flags[flag] = true
+	}
+	p.Scene++
+	c.normalize(p)
+	return scene
+}
+
+func (c *Campaign) ChapterIndex(id string) int {
+	for i, chapter := range c.Chapters {
+		if chapter.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *Campaign) Finished(p *Progress) bool {
+	return p.Chapter >= len(c.Chapters)
+}
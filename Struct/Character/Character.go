@@ -3,12 +3,15 @@ package Character
 import (
 	"fmt"
 	"math/rand"
-	"time"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
-	icharacter "MyGame/Interface"
 	"MyGame/Struct/Equipment"
 	"MyGame/Struct/Inventory"
 	"MyGame/Struct/Item"
+	"MyGame/combat"
+	"MyGame/events"
 )
 
 const (
@@ -16,8 +19,58 @@ const (
 	MinStrength     = 1
 	MinAgility      = 1
 	MinIntelligence = 1
+	MinAttackSpeed  = 0.3
+
+	BaseHealthRegen = 0.5
+	BaseManaRegen   = 1.0
+	MaxCritChance   = 0.75
+	MaxDodgeChance  = 0.6
+	MaxLifesteal    = 0.5
+
+	MaxLevel       = 30
+	LevelHPGain    = 10
+	PointsPerLevel = 3
+	HPPerPoint     = 5
+
+	RespecGoldPerLevel      = 25
+	MagicAmpPerIntelligence = 0.01
+)
+
+type Attribute int
+
+const (
+	AttrHP Attribute = iota
+	AttrStrength
+	AttrAgility
+	AttrIntelligence
 )
 
+var Attributes = []Attribute{AttrHP, AttrStrength, AttrAgility, AttrIntelligence}
+
+func (a Attribute) String() string {
+	names := []string{"Здоровье", "Сила", "Ловкость", "Интеллект"}
+	if int(a) < len(names) {
+		return names[a]
+	}
+	return "Неизвестно"
+}
+
+type Allocation [4]int
+
+func (a Allocation) Total() int {
+	total := 0
+	for _, n := range a {
+		total += n
+	}
+	return total
+}
+
+type LevelUp struct {
+	Level  int
+	HP     int
+	Points int
+}
+
 type Character struct {
 	Name         string
 	CurrentHP    int
@@ -26,6 +79,12 @@ type Character struct {
 	Agility      int
 	Intelligence int
 	Description  string
+	Level        int
+	XP           int
+	StatPoints   int
+	Allocated    Allocation
+	Gold         int
+	Class        string
 
 	BaseHP           int
 	BaseStrength     int
@@ -44,11 +103,20 @@ type Character struct {
 	Mana         float32
 	MaxMana      float32
 	ManaRegen    float32
+	Evasion      float32
+	Lifesteal    float32
+	MagicAmp     float32
+
+	Events   *events.Bus
+	Injuries []combat.Injury
+
+	regenCarry float32
+	rng        *rand.Rand
 }
 
 var (
-	bodyParts = []string{"Голова", "Тело", "Правая нога", "Левая нога", "Правая рука", "Левая рука"}
-	rng       = rand.New(rand.NewSource(time.Now().UnixNano()))
+	bodyParts  = []string{"Голова", "Тело", "Правая нога", "Левая нога", "Правая рука", "Левая рука"}
+	defaultRNG = rand.New(rand.NewSource(combat.DefaultSeed))
 )
 
 func New(name string, hp, strength, agility, intelligence int) (*Character, error) {
@@ -58,6 +126,7 @@ func New(name string, hp, strength, agility, intelligence int) (*Character, erro
 
 	char := &Character{
 		Name:             name,
+		Level:            1,
 		CurrentHP:        hp,
 		MaxHP:            hp,
 		Strength:         strength,
@@ -73,10 +142,10 @@ func New(name string, hp, strength, agility, intelligence int) (*Character, erro
 		DefenseValue:     0,
 		CritChance:       0.1,
 		CritDamage:       1.5,
-		HealthRegen:      0.5,
+		HealthRegen:      BaseHealthRegen,
 		MaxMana:          100,
 		Mana:             100,
-		ManaRegen:        1.0,
+		ManaRegen:        BaseManaRegen,
 	}
 
 	char.AddStarterItems()
@@ -100,16 +169,125 @@ func validateBaseStats(hp, strength, agility, intelligence int) error {
 	return nil
 }
 
+type Class struct {
+	ID           string
+	Name         string
+	Description  string
+	HP           int
+	Strength     int
+	Agility      int
+	Intelligence int
+	Kit          []int
+}
+
+var (
+	ClassWarrior = Class{ID: "warrior", Name: "Воин", Description: "Крепкий боец ближнего боя: много здоровья и сильный удар",
+		HP: 100, Strength: 15, Agility: 8, Intelligence: 5, Kit: []int{30}}
+	ClassMage = Class{ID: "mage", Name: "Маг", Description: "Хрупкий, но зелья и амулеты в его руках действуют сильнее",
+		HP: 70, Strength: 5, Agility: 8, Intelligence: 15, Kit: []int{28, 22}}
+	ClassRogue = Class{ID: "rogue", Name: "Разбойник", Description: "Ловкий и быстрый: чаще ходит, уклоняется и бьёт критом",
+		HP: 80, Strength: 8, Agility: 15, Intelligence: 5, Kit: []int{24, 34}}
+
+	Classes = []Class{ClassWarrior, ClassMage, ClassRogue}
+)
+
+const (
+	CreationPoints = 5
+	MaxNameLength  = 20
+)
+
+func ClassByID(id string) (Class, bool) {
+	for _, class := range Classes {
+		if class.ID == id {
+			return class, true
+		}
+	}
+	return Class{}, false
+}
+
+func (c Class) Stats(points Allocation) Allocation {
+	return Allocation{
+		AttrHP:           c.HP + points[AttrHP]*HPPerPoint,
+		AttrStrength:     c.Strength + points[AttrStrength],
+		AttrAgility:      c.Agility + points[AttrAgility],
+		AttrIntelligence: c.Intelligence + points[AttrIntelligence],
+	}
+}
+
+func ValidateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("имя не может быть пустым")
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return "", fmt.Errorf("имя длиннее %d символов", MaxNameLength)
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.Mn, r) && r != ' ' && r != '-' && r != '\'' {
+			return "", fmt.Errorf("недопустимый символ в имени: %q", r)
+		}
+	}
+	return name, nil
+}
+
+func Create(name string, class Class, points Allocation) (*Character, error) {
+	name, err := ValidateName(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range points {
+		if n < 0 {
+			return nil, fmt.Errorf("нельзя вложить отрицательное число очков")
+		}
+	}
+	if points.Total() > CreationPoints {
+		return nil, fmt.Errorf("при создании можно распределить не больше %d очков", CreationPoints)
+	}
+	stats := class.Stats(points)
+	char, err := New(name, stats[AttrHP], stats[AttrStrength], stats[AttrAgility], stats[AttrIntelligence])
+	if err != nil {
+		return nil, err
+	}
+	char.Class = class.ID
+	for _, item := range StartingKit(class) {
+		if err := char.Inventory.AddItem(item); err != nil {
+			return nil, err
+		}
+	}
+	items := char.Inventory.GetItems()
+	for i := len(items) - 1; i >= 0; i-- {
+		item := items[i]
+		if item.Template.Slot != Item.SlotNone && char.Equipment.GetItem(item.Template.Slot) == nil {
+			if err := char.EquipFromInventory(i); err != nil {
+				return nil, err
+			}
+		}
+	}
+	char.CalculateStats()
+	char.CurrentHP = char.MaxHP
+	return char, nil
+}
+
+func StartingKit(class Class) []*Item.Item {
+	kit := make([]*Item.Item, 0, len(class.Kit))
+	for _, id := range class.Kit {
+		if item, err := Item.CreateItem(id, Item.Common, 1); err == nil {
+			kit = append(kit, item)
+		}
+	}
+	return kit
+}
+
 func NewWarrior(name string) (*Character, error) {
-	return New(name, 100, 15, 8, 5)
+	return New(name, ClassWarrior.HP, ClassWarrior.Strength, ClassWarrior.Agility, ClassWarrior.Intelligence)
 }
 
 func NewMage(name string) (*Character, error) {
-	return New(name, 70, 5, 8, 15)
+	return New(name, ClassMage.HP, ClassMage.Strength, ClassMage.Agility, ClassMage.Intelligence)
 }
 
 func NewRogue(name string) (*Character, error) {
-	return New(name, 80, 8, 15, 5)
+	return New(name, ClassRogue.HP, ClassRogue.Strength, ClassRogue.Agility, ClassRogue.Intelligence)
 }
 
 func (c *Character) AddStarterItems() {
@@ -121,42 +299,27 @@ func (c *Character) AddStarterItems() {
 	}
 }
 
-func (c *Character) Hit() string {
-	return bodyParts[rng.Intn(len(bodyParts))]
-}
-
-func (c *Character) Block() string {
-	return bodyParts[rng.Intn(len(bodyParts))]
+func (c *Character) SetRNG(r *rand.Rand) {
+	c.rng = r
 }
 
-func (c *Character) Attack(target icharacter.ICharacter, attackPart, blockPart string) {
-	if !c.IsAlive() {
-		fmt.Printf("%s не может атаковать, так как мертв\n", c.Name)
-		return
-	}
-
-	if !target.IsAlive() {
-		fmt.Printf("%s не может атаковать мертвого %s\n", c.Name, target.GetName())
-		return
+func (c *Character) random() *rand.Rand {
+	if c.rng != nil {
+		return c.rng
 	}
+	return defaultRNG
+}
 
-	if attackPart != blockPart {
-		baseDamage := c.Strength + int(c.GetAttack())
+func (c *Character) Hit() string {
+	return bodyParts[c.random().Intn(len(bodyParts))]
+}
 
-		isCritical := rng.Float32() < c.CritChance
-		damage := baseDamage
-		if isCritical {
-			damage = int(float32(baseDamage) * c.CritDamage)
-			fmt.Printf("КРИТИЧЕСКИЙ УДАР! ")
-		}
+func (c *Character) Block() string {
+	return bodyParts[c.random().Intn(len(bodyParts))]
+}
 
-		target.TakeDamage(damage)
-		fmt.Printf("%s атакует %s в %s и наносит %d урона. %s HP = %d/%d\n",
-			c.Name, target.GetName(), attackPart, damage, target.GetName(), target.GetHP(), target.GetMaxHP())
-	} else {
-		fmt.Printf("%s блокирует атаку %s в %s и урон не наносится\n",
-			target.GetName(), c.Name, attackPart)
-	}
+func (c *Character) emit(e events.Event) {
+	c.Events.Emit(e)
 }
 
 func (c *Character) GetHP() int {
@@ -192,22 +355,11 @@ func (c *Character) TakeDamage(damage int) {
 		return
 	}
 
-	dodgeChance := float32(c.Agility) * 0.01
-	if rng.Float32() < dodgeChance {
-		fmt.Printf("%s увернулся от атаки!\n", c.Name)
-		return
-	}
-
-	actualDamage := damage - int(c.DefenseValue)
-
-	if actualDamage < 1 {
-		actualDamage = 1
-	}
-
-	c.CurrentHP = max(MinHP, c.CurrentHP-actualDamage)
+	c.CurrentHP = max(MinHP, c.CurrentHP-damage)
 
 	if c.CurrentHP == 0 {
-		fmt.Printf("%s погиб!\n", c.Name)
+		c.emit(events.Event{Kind: events.Death, Actor: c.Name, Amount: float64(damage),
+			Message: fmt.Sprintf("%s погиб!", c.Name)})
 	}
 }
 
@@ -222,8 +374,8 @@ func (c *Character) Heal(amount float32) {
 	actualHeal := c.CurrentHP - oldHP
 
 	if actualHeal > 0 {
-		fmt.Printf("%s восстанавливает %d HP (теперь %d/%d)\n",
-			c.Name, actualHeal, c.CurrentHP, c.MaxHP)
+		c.emit(events.Event{Kind: events.Heal, Actor: c.Name, Amount: float64(actualHeal),
+			Message: fmt.Sprintf("%s восстанавливает %d HP (теперь %d/%d)", c.Name, actualHeal, c.CurrentHP, c.MaxHP)})
 	}
 }
 
@@ -248,10 +400,184 @@ func (c *Character) GetAttack() float32 {
 	return c.AttackValue
 }
 
+func (c *Character) GetAttackSpeed() float32 {
+	return c.AttackSpeed
+}
+
+func (c *Character) GetCritChance() float32 {
+	return c.CritChance
+}
+
+func (c *Character) GetCritDamage() float32 {
+	return c.CritDamage
+}
+
+func (c *Character) GetDodgeChance() float32 {
+	return min(float32(c.Agility)*0.01+c.Evasion, MaxDodgeChance)
+}
+
+func (c *Character) GetEvasion() float32 {
+	return c.Evasion
+}
+
+func (c *Character) GetLifesteal() float32 {
+	return c.Lifesteal
+}
+
+func (c *Character) GetMagicAmp() float32 {
+	return c.MagicAmp
+}
+
+func (c *Character) Regenerate() (hp int, mana float32) {
+	if !c.IsAlive() {
+		return 0, 0
+	}
+	c.regenCarry += c.HealthRegen * (1 + c.MagicAmp)
+	whole := int(c.regenCarry)
+	c.regenCarry -= float32(whole)
+
+	oldHP, oldMana := c.CurrentHP, c.Mana
+	c.CurrentHP = min(c.CurrentHP+whole, c.MaxHP)
+	c.SetMana(c.Mana + c.ManaRegen*(1+c.MagicAmp))
+	return c.CurrentHP - oldHP, c.Mana - oldMana
+}
+
+func (c *Character) GetLevel() int {
+	return max(c.Level, 1)
+}
+
+func (c *Character) GainXP(amount int, next func(level int) int) []LevelUp {
+	if amount <= 0 || c.GetLevel() >= MaxLevel {
+		return nil
+	}
+	c.Level = c.GetLevel()
+	c.XP += amount
+	var ups []LevelUp
+	for c.Level < MaxLevel && c.XP >= next(c.Level) {
+		c.XP -= next(c.Level)
+		ups = append(ups, c.levelUp())
+	}
+	if c.Level >= MaxLevel {
+		c.XP = 0
+	}
+	return ups
+}
+
+func (c *Character) levelUp() LevelUp {
+	c.Level++
+	c.BaseHP += LevelHPGain
+	c.StatPoints += PointsPerLevel
+	c.CalculateStats()
+	c.CurrentHP = c.MaxHP
+	return LevelUp{Level: c.Level, HP: LevelHPGain, Points: PointsPerLevel}
+}
+
+func (c *Character) addToBase(attr Attribute, points int) {
+	switch attr {
+	case AttrHP:
+		c.BaseHP += points * HPPerPoint
+	case AttrStrength:
+		c.BaseStrength += points
+	case AttrAgility:
+		c.BaseAgility += points
+	case AttrIntelligence:
+		c.BaseIntelligence += points
+	}
+}
+
+func (c *Character) Allocate(points Allocation) error {
+	for _, n := range points {
+		if n < 0 {
+			return fmt.Errorf("нельзя вложить отрицательное число очков")
+		}
+	}
+	if points.Total() > c.StatPoints {
+		return fmt.Errorf("недостаточно очков характеристик: нужно %d, есть %d", points.Total(), c.StatPoints)
+	}
+	hpBefore := c.MaxHP
+	for _, attr := range Attributes {
+		c.addToBase(attr, points[attr])
+		c.Allocated[attr] += points[attr]
+	}
+	c.StatPoints -= points.Total()
+	c.CalculateStats()
+	c.CurrentHP = min(c.MaxHP, c.CurrentHP+max(0, c.MaxHP-hpBefore))
+	return nil
+}
+
+func (c *Character) Preview(points Allocation) *Character {
+	preview := *c
+	for _, attr := range Attributes {
+		preview.addToBase(attr, points[attr])
+	}
+	preview.CalculateStats()
+	return &preview
+}
+
+func (c *Character) RespecCost() int {
+	return c.GetLevel() * RespecGoldPerLevel
+}
+
+func (c *Character) Respec() error {
+	if c.Allocated.Total() == 0 {
+		return fmt.Errorf("нет вложенных очков для сброса")
+	}
+	if c.Gold < c.RespecCost() {
+		return fmt.Errorf("для сброса нужно %d зол., есть %d", c.RespecCost(), c.Gold)
+	}
+	c.Gold -= c.RespecCost()
+	for _, attr := range Attributes {
+		c.addToBase(attr, -c.Allocated[attr])
+	}
+	c.StatPoints += c.Allocated.Total()
+	c.Allocated = Allocation{}
+	c.CalculateStats()
+	return nil
+}
+
+func (c *Character) GetInjuries() []combat.Injury {
+	return c.Injuries
+}
+
+func (c *Character) AddInjury(injury combat.Injury) {
+	for i, existing := range c.Injuries {
+		if existing.Kind == injury.Kind && existing.Zone == injury.Zone {
+			c.Injuries[i].Turns = max(existing.Turns, injury.Turns)
+			return
+		}
+	}
+	c.Injuries = append(c.Injuries, injury)
+}
+
+func (c *Character) TickInjuries() []combat.Injury {
+	var healed []combat.Injury
+	kept := c.Injuries[:0]
+	for _, injury := range c.Injuries {
+		injury.Turns--
+		if injury.Turns <= 0 {
+			healed = append(healed, injury)
+			continue
+		}
+		kept = append(kept, injury)
+	}
+	c.Injuries = kept
+	return healed
+}
+
+func (c *Character) CureInjuries() int {
+	n := len(c.Injuries)
+	c.Injuries = nil
+	return n
+}
+
 func (c *Character) GetDefense() float32 {
 	return c.DefenseValue
 }
 
+func (c *Character) GetZoneDefense(part string) float32 {
+	return max(0, c.DefenseValue-c.Equipment.ArmorDefense()+c.Equipment.ZoneArmor(part))
+}
+
 func (c *Character) SetDefense(defense float32) {
 	if defense < 0 {
 		defense = 0
@@ -307,10 +633,88 @@ func (c *Character) EquipItem(itemID int) error {
 	}
 
 	c.CalculateStats()
-	fmt.Printf("%s экипировал %s\n", c.Name, item.Template.Name)
+	c.emit(events.Event{Kind: events.ItemEquipped, Actor: c.Name, Item: item.Template.Name,
+		Message: fmt.Sprintf("%s экипировал %s", c.Name, item.Template.Name)})
 	return nil
 }
 
+func (c *Character) EquipFromInventory(index int) error {
+	items := c.Inventory.GetItems()
+	if index < 0 || index >= len(items) {
+		return fmt.Errorf("в инвентаре нет ячейки %d", index)
+	}
+	item := items[index]
+	if item == nil || item.Template == nil || item.Template.Slot == Item.SlotNone {
+		return fmt.Errorf("этот предмет нельзя экипировать")
+	}
+	slot := item.Template.Slot
+	previous := c.Equipment.GetItem(slot)
+	if previous != nil {
+		if _, err := c.Equipment.Unequip(slot); err != nil {
+			return err
+		}
+	}
+	if !c.Equipment.CanEquipItem(item) {
+		if previous != nil {
+			_ = c.Equipment.Equip(previous)
+		}
+		return fmt.Errorf("предмет '%s' нельзя экипировать", item.Template.Name)
+	}
+	if _, err := c.Inventory.RemoveAt(index); err != nil {
+		if previous != nil {
+			_ = c.Equipment.Equip(previous)
+		}
+		return err
+	}
+	if err := c.Equipment.Equip(item); err != nil {
+		return err
+	}
+	if previous != nil {
+		_ = c.Inventory.AddItem(previous)
+	}
+
+	c.CalculateStats()
+	c.emit(events.Event{Kind: events.ItemEquipped, Actor: c.Name, Item: item.Template.Name,
+		Message: fmt.Sprintf("%s экипировал %s", c.Name, item.Template.Name)})
+	return nil
+}
+
+func (c *Character) WearWeapon() []string {
+	return c.wear([]Item.EquipmentSlot{Item.SlotWeapon})
+}
+
+func (c *Character) WearArmor(part string) []string {
+	zone, ok := Equipment.ZoneFor(part)
+	if !ok {
+		return nil
+	}
+	return c.wear(zone.Slots)
+}
+
+func (c *Character) wear(slots []Item.EquipmentSlot) []string {
+	var broken []string
+	changed := false
+	for _, slot := range slots {
+		item := c.Equipment.GetItem(slot)
+		if item == nil || item.IsBroken() {
+			continue
+		}
+		before := item.Condition()
+		item.Wear(1)
+		if item.Condition() == before {
+			continue
+		}
+		changed = true
+		if item.IsBroken() {
+			broken = append(broken, item.Template.Name)
+		}
+	}
+	if changed {
+		c.CalculateStats()
+	}
+	return broken
+}
+
 func (c *Character) UnequipItem(slot Item.EquipmentSlot) error {
 	item, err := c.Equipment.Unequip(slot)
 	if err != nil {
@@ -324,7 +728,8 @@ func (c *Character) UnequipItem(slot Item.EquipmentSlot) error {
 	}
 
 	c.CalculateStats()
-	fmt.Printf("%s снял %s\n", c.Name, item.Template.Name)
+	c.emit(events.Event{Kind: events.ItemUnequipped, Actor: c.Name, Item: item.Template.Name,
+		Message: fmt.Sprintf("%s снял %s", c.Name, item.Template.Name)})
 	return nil
 }
 
@@ -346,8 +751,16 @@ func (c *Character) CalculateStats() {
 	c.AttackValue += bonuses.Attack
 	c.DefenseValue += bonuses.Defense
 	c.MaxHP += int(bonuses.Health)
+	c.AttackSpeed = max(MinAttackSpeed, 1+c.Equipment.GetAttackSpeedBonus())
 
-	c.CritChance += float32(c.Agility) * 0.001
+	c.HealthRegen = BaseHealthRegen + bonuses.HealthRegen
+	c.ManaRegen = BaseManaRegen + bonuses.ManaRegen
+	c.Evasion = bonuses.Evasion
+	c.Lifesteal = min(bonuses.Lifesteal, MaxLifesteal)
+	c.MagicAmp = bonuses.MagicAmp + float32(c.Intelligence)*MagicAmpPerIntelligence
+
+	c.CritChance += float32(c.Agility)*0.001 + bonuses.CriticalChance
+	c.CritChance = min(c.CritChance, MaxCritChance)
 
 	if c.CurrentHP > c.MaxHP {
 		c.CurrentHP = c.MaxHP
@@ -355,21 +768,27 @@ func (c *Character) CalculateStats() {
 }
 
 func (c *Character) ShowCharacterInfo() {
-	fmt.Printf("\n=== ИНФОРМАЦИЯ О ПЕРСОНАЖЕ ===\n")
-	fmt.Printf("Имя: %s\n", c.Name)
-	fmt.Printf("Состояние: %s\n", c.GetStatus())
-	fmt.Printf("Здоровье: %d/%d\n", c.CurrentHP, c.MaxHP)
-	fmt.Printf("Сила: %d\n", c.Strength)
-	fmt.Printf("Ловкость: %d\n", c.Agility)
-	fmt.Printf("Интеллект: %d\n", c.Intelligence)
-	fmt.Printf("Атака: %.1f\n", c.AttackValue)
-	fmt.Printf("Защита: %.1f\n", c.DefenseValue)
-	fmt.Printf("Шанс крита: %.1f%%\n", c.CritChance*100)
-	fmt.Printf("Урон крита: %.1f%%\n", c.CritDamage*100)
-	fmt.Printf("Мана: %.1f/%.1f\n", c.Mana, c.MaxMana)
-
-	c.Equipment.Display()
-	fmt.Println()
+	var b strings.Builder
+	b.WriteString("=== ИНФОРМАЦИЯ О ПЕРСОНАЖЕ ===\n")
+	fmt.Fprintf(&b, "Имя: %s\n", c.Name)
+	fmt.Fprintf(&b, "Состояние: %s\n", c.GetStatus())
+	fmt.Fprintf(&b, "Здоровье: %d/%d\n", c.CurrentHP, c.MaxHP)
+	fmt.Fprintf(&b, "Сила: %d\n", c.Strength)
+	fmt.Fprintf(&b, "Ловкость: %d\n", c.Agility)
+	fmt.Fprintf(&b, "Интеллект: %d\n", c.Intelligence)
+	fmt.Fprintf(&b, "Атака: %.1f\n", c.AttackValue)
+	fmt.Fprintf(&b, "Защита: %.1f\n", c.DefenseValue)
+	fmt.Fprintf(&b, "Скорость атаки: %.2f\n", c.AttackSpeed)
+	fmt.Fprintf(&b, "Шанс крита: %.1f%%\n", c.CritChance*100)
+	fmt.Fprintf(&b, "Урон крита: %.1f%%\n", c.CritDamage*100)
+	fmt.Fprintf(&b, "Мана: %.1f/%.1f\n", c.Mana, c.MaxMana)
+	fmt.Fprintf(&b, "Уклонение: %.1f%%\n", c.Evasion*100)
+	fmt.Fprintf(&b, "Вампиризм: %.1f%%\n", c.Lifesteal*100)
+	fmt.Fprintf(&b, "Усиление магии: %.1f%%\n", c.MagicAmp*100)
+	fmt.Fprintf(&b, "Регенерация: %.1f HP / %.1f MP за раунд\n", c.HealthRegen, c.ManaRegen)
+
+	b.WriteString(c.Equipment.Summary())
+	c.emit(events.Event{Kind: events.Info, Actor: c.Name, Message: b.String()})
 }
 
 func (c *Character) GetStatus() string {
@@ -408,6 +827,8 @@ func (c *Character) UseItem(itemID int) error {
 	if err != nil {
 		return err
 	}
+	c.emit(events.Event{Kind: events.ItemUsed, Actor: c.Name, Item: item.Template.Name,
+		Message: fmt.Sprintf("%s использует %s", c.Name, item.Template.Name)})
 
 	for effect, value := range effects {
 		switch effect {
@@ -415,16 +836,18 @@ func (c *Character) UseItem(itemID int) error {
 			c.Heal(value)
 		case "mana":
 			c.Mana = minFloat32(c.Mana+value, c.MaxMana)
-			fmt.Printf("%s восстанавливает %.1f маны (теперь %.1f/%.1f)\n", c.Name, value, c.Mana, c.MaxMana)
+			c.emit(events.Event{Kind: events.ManaRestore, Actor: c.Name, Item: item.Template.Name, Amount: float64(value),
+				Message: fmt.Sprintf("%s восстанавливает %.1f маны (теперь %.1f/%.1f)", c.Name, value, c.Mana, c.MaxMana)})
 		case "attack", "defense":
-
-			fmt.Printf("%s получает временный бафф %s: +%.1f\n", c.Name, effect, value)
+			c.emit(events.Event{Kind: events.Buff, Actor: c.Name, Item: item.Template.Name, Amount: float64(value),
+				Message: fmt.Sprintf("%s получает временный бафф %s: +%.1f", c.Name, effect, value)})
 		}
 	}
 
 	if item.Durability <= 0 {
 		if _, err := c.Inventory.RemoveItem(itemID); err != nil {
-			fmt.Printf("не удалось удалить использованный предмет из инвентаря: %v\n", err)
+			c.emit(events.Event{Kind: events.Failure, Actor: c.Name, Item: item.Template.Name,
+				Message: fmt.Sprintf("не удалось удалить использованный предмет из инвентаря: %v", err)})
 		}
 	}
 
@@ -0,0 +1,211 @@
+package Character_test
+
+import (
+	"strings"
+	"testing"
+
+	"MyGame/Struct/Character"
+	"MyGame/Struct/Character/chartest"
+	"MyGame/Struct/Item"
+	"MyGame/events"
+)
+
+func newTestCharacter(t *testing.T) (*Character.Character, *[]events.Event) {
+	t.Helper()
+	c := chartest.New(t)
+	c.Events = events.NewBus()
+	var got []events.Event
+	c.Events.SubscribeAll(func(e events.Event) { got = append(got, e) })
+	return c, &got
+}
+
+func TestCharacterEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		act    func(c *Character.Character)
+		kind   events.Kind
+		amount float64
+		count  int
+	}{
+		{"лечение раненого", func(c *Character.Character) { c.SetHP(40); c.Heal(25) }, events.Heal, 25, 1},
+		{"лечение сверх максимума", func(c *Character.Character) { c.SetHP(c.MaxHP - 5); c.Heal(50) }, events.Heal, 5, 1},
+		{"лечение здорового", func(c *Character.Character) { c.Heal(10) }, events.Heal, 0, 0},
+		{"несмертельный урон", func(c *Character.Character) { c.TakeDamage(10) }, events.Death, 0, 0},
+		{"смертельный урон", func(c *Character.Character) { c.TakeDamage(c.MaxHP + 50) }, events.Death, 0, 1},
+		{"сведения о персонаже", func(c *Character.Character) { c.ShowCharacterInfo() }, events.Info, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, got := newTestCharacter(t)
+			tt.act(c)
+			var matched []events.Event
+			for _, e := range *got {
+				if e.Kind == tt.kind {
+					matched = append(matched, e)
+				}
+			}
+			if len(matched) != tt.count {
+				t.Fatalf("событий %s: %d, ожидалось %d (%v)", tt.kind, len(matched), tt.count, *got)
+			}
+			if tt.count == 0 {
+				return
+			}
+			e := matched[0]
+			if e.Actor != c.Name {
+				t.Errorf("Actor = %q, ожидалось %q", e.Actor, c.Name)
+			}
+			if tt.kind == events.Heal && e.Amount != tt.amount {
+				t.Errorf("Amount = %v, ожидалось %v", e.Amount, tt.amount)
+			}
+			if tt.kind == events.Death && c.IsAlive() {
+				t.Error("персонаж жив после события смерти")
+			}
+			if tt.kind == events.Info && !strings.Contains(e.Message, "ЭКИПИРОВКА") {
+				t.Errorf("в сведениях нет экипировки: %q", e.Message)
+			}
+		})
+	}
+}
+
+func TestRespecChargesGold(t *testing.T) {
+	tests := []struct {
+		name     string
+		gold     int
+		points   Character.Allocation
+		wantErr  bool
+		wantGold int
+	}{
+		{"хватает золота", 100, Character.Allocation{Character.AttrStrength: 2}, false, 100 - Character.RespecGoldPerLevel},
+		{"не хватает золота", Character.RespecGoldPerLevel - 1, Character.Allocation{Character.AttrStrength: 2}, true, Character.RespecGoldPerLevel - 1},
+		{"нечего сбрасывать", 100, Character.Allocation{}, true, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := chartest.New(t)
+			c.StatPoints = tt.points.Total()
+			if tt.points.Total() > 0 {
+				if err := c.Allocate(tt.points); err != nil {
+					t.Fatalf("Allocate: %v", err)
+				}
+			}
+			strength, xp := c.Strength, c.XP
+			c.Gold = tt.gold
+			err := c.Respec()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Respec() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if c.Gold != tt.wantGold || c.XP != xp {
+				t.Errorf("золото %d, опыт %d; ожидалось %d и %d", c.Gold, c.XP, tt.wantGold, xp)
+			}
+			if !tt.wantErr && (c.StatPoints != tt.points.Total() || c.Strength != strength-tt.points[Character.AttrStrength]) {
+				t.Errorf("очки %d, сила %d после сброса", c.StatPoints, c.Strength)
+			}
+		})
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"кириллица", "Добрыня", "Добрыня", false},
+		{"пробелы по краям", "  Илья Муромец  ", "Илья Муромец", false},
+		{"дефис и апостроф", "Жан-Поль д'Арк", "Жан-Поль д'Арк", false},
+		{"комбинируемое ударение", "Але\u0308ша", "Але\u0308ша", false},
+		{"комбинируемая диакритика", "Jose\u0301", "Jose\u0301", false},
+		{"пустое", "   ", "", true},
+		{"знак препинания", "Герой!", "", true},
+		{"слишком длинное", strings.Repeat("я", Character.MaxNameLength+1), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Character.ValidateName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Character.ValidateName(%q) err = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Character.ValidateName(%q) = %q, ожидалось %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGainXP(t *testing.T) {
+	flat := func(int) int { return 100 }
+	tests := []struct {
+		name      string
+		level     int
+		xp        int
+		gain      int
+		wantLevel int
+		wantXP    int
+		wantUps   int
+	}{
+		{"без повышения", 1, 0, 60, 1, 60, 0},
+		{"ровно до уровня", 1, 40, 60, 2, 0, 1},
+		{"несколько уровней сразу", 1, 0, 250, 3, 50, 2},
+		{"отрицательный опыт игнорируется", 1, 30, -10, 1, 30, 0},
+		{"максимальный уровень", Character.MaxLevel, 0, 500, Character.MaxLevel, 0, 0},
+		{"упор в максимальный уровень", Character.MaxLevel - 1, 0, 1000, Character.MaxLevel, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := chartest.New(t)
+			c.Level, c.XP = tt.level, tt.xp
+			hp, points := c.BaseHP, c.StatPoints
+			ups := c.GainXP(tt.gain, flat)
+			if len(ups) != tt.wantUps || c.Level != tt.wantLevel || c.XP != tt.wantXP {
+				t.Fatalf("уровень %d, опыт %d, повышений %d; ожидалось %d, %d, %d",
+					c.Level, c.XP, len(ups), tt.wantLevel, tt.wantXP, tt.wantUps)
+			}
+			if c.BaseHP != hp+tt.wantUps*Character.LevelHPGain || c.StatPoints != points+tt.wantUps*Character.PointsPerLevel {
+				t.Errorf("HP %d→%d, очки %d→%d", hp, c.BaseHP, points, c.StatPoints)
+			}
+			if tt.wantUps > 0 && (ups[len(ups)-1].Level != c.Level || c.GetHP() != c.GetMaxHP()) {
+				t.Errorf("последнее повышение %+v, HP %d/%d", ups[len(ups)-1], c.GetHP(), c.GetMaxHP())
+			}
+		})
+	}
+}
+
+func TestWearDegradesEquipment(t *testing.T) {
+	tests := []struct {
+		name       string
+		durability int
+		hits       int
+		wantDur    int
+		wantBroken []string
+	}{
+		{"удар стирает единицу", 100, 3, 97, nil},
+		{"последний удар ломает", 2, 2, 0, []string{"broken"}},
+		{"сломанный больше не изнашивается", 0, 2, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := chartest.New(t)
+			naked := c.GetAttack()
+			weapon, err := Item.CreateItem(24, Item.Common, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := c.Equipment.Equip(weapon); err != nil {
+				t.Fatal(err)
+			}
+			weapon.Durability = tt.durability
+			c.CalculateStats()
+			var broken []string
+			for i := 0; i < tt.hits; i++ {
+				broken = append(broken, c.WearWeapon()...)
+			}
+			if weapon.Durability != tt.wantDur || len(broken) != len(tt.wantBroken) {
+				t.Errorf("прочность %d, сломано %v", weapon.Durability, broken)
+			}
+			if weapon.IsBroken() && c.GetAttack() != naked {
+				t.Errorf("сломанное оружие всё ещё даёт атаку: %.1f", c.GetAttack())
+			}
+		})
+	}
+}
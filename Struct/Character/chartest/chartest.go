@@ -0,0 +1,31 @@
+package chartest
+
+import (
+	"testing"
+
+	"MyGame/Struct/Character"
+	"MyGame/Struct/Item"
+)
+
+func New(t testing.TB) *Character.Character {
+	t.Helper()
+	c, err := Character.New("Герой", 100, 10, 10, 10)
+	if err != nil {
+		t.Fatalf("Character.New: %v", err)
+	}
+	c.CalculateStats()
+	c.GetInventory().Items = nil
+	return c
+}
+
+func Give(t testing.TB, c *Character.Character, id int, rarity Item.Rarity, level int) *Item.Item {
+	t.Helper()
+	item, err := Item.CreateItem(id, rarity, level)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.GetInventory().AddItem(item); err != nil {
+		t.Fatal(err)
+	}
+	return item
+}
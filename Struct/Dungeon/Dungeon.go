@@ -0,0 +1,265 @@
+package Dungeon
+
+import (
+	"fmt"
+	"math/rand"
+
+	"MyGame/Struct/Bestiary"
+)
+
+type RoomKind int
+
+const (
+	RoomEmpty RoomKind = iota
+	RoomStart
+	RoomBattle
+	RoomTreasure
+	RoomTrap
+	RoomRest
+	RoomExit
+)
+
+const (
+	Width     = 9
+	Height    = 5
+	RoomCount = 16
+	TrapShare = 0.15
+	RestShare = 0.5
+)
+
+var kindWeights = []struct {
+	kind   RoomKind
+	weight int
+}{
+	{RoomBattle, 40},
+	{RoomTreasure, 20},
+	{RoomTrap, 15},
+	{RoomRest, 10},
+	{RoomEmpty, 15},
+}
+
+type Room struct {
+	Kind      RoomKind
+	Encounter string
+	Visited   bool
+	Cleared   bool
+}
+
+type Dungeon struct {
+	Depth  int
+	Rooms  [][]*Room
+	X, Y   int
+	HP     int
+	Failed bool
+	prevX  int
+	prevY  int
+}
+
+func (k RoomKind) String() string {
+	names := []string{"Пустая комната", "Вход", "Логово врагов", "Сокровищница", "Ловушка", "Привал", "Спуск"}
+	if int(k) < len(names) {
+		return names[k]
+	}
+	return "Неизвестно"
+}
+
+func Generate(rng *rand.Rand, depth int, bestiary *Bestiary.Bestiary) (*Dungeon, error) {
+	if bestiary == nil || len(bestiary.Encounters()) == 0 {
+		return nil, fmt.Errorf("в бестиарии нет встреч для подземелья")
+	}
+	d := newDungeon(depth, Width/2, Height/2)
+	cells := [][2]int{{d.X, d.Y}}
+	d.Rooms[d.Y][d.X] = &Room{Kind: RoomStart, Visited: true, Cleared: true}
+	x, y := d.X, d.Y
+	for len(cells) < RoomCount {
+		dx, dy := direction(rng.Intn(4))
+		if !d.inside(x+dx, y+dy) {
+			continue
+		}
+		x, y = x+dx, y+dy
+		if d.Rooms[y][x] == nil {
+			d.Rooms[y][x] = &Room{}
+			cells = append(cells, [2]int{x, y})
+		}
+	}
+
+	exit := d.farthest()
+	for _, cell := range cells[1:] {
+		room := d.Rooms[cell[1]][cell[0]]
+		if cell == exit {
+			room.Kind = RoomExit
+			continue
+		}
+		room.Kind = rollKind(rng)
+		if room.Kind == RoomBattle {
+			room.Encounter = rollEncounter(rng, depth, bestiary).ID
+		}
+	}
+	return d, nil
+}
+
+func newDungeon(depth, x, y int) *Dungeon {
+	d := &Dungeon{Depth: max(depth, 1), X: x, Y: y, prevX: x, prevY: y}
+	d.Rooms = make([][]*Room, Height)
+	for row := range d.Rooms {
+		d.Rooms[row] = make([]*Room, Width)
+	}
+	return d
+}
+
+func Restore(depth, x, y, hp int, failed bool, rooms map[[2]int]*Room) (*Dungeon, error) {
+	d := newDungeon(depth, x, y)
+	for cell, room := range rooms {
+		if !d.inside(cell[0], cell[1]) || room == nil {
+			return nil, fmt.Errorf("комната подземелья вне карты: %d,%d", cell[0], cell[1])
+		}
+		d.Rooms[cell[1]][cell[0]] = room
+	}
+	if d.Current() == nil {
+		return nil, fmt.Errorf("герой стоит вне комнат подземелья: %d,%d", x, y)
+	}
+	d.HP, d.Failed = max(hp, 1), failed
+	return d, nil
+}
+
+func direction(n int) (int, int) {
+	switch n {
+	case 0:
+		return 0, -1
+	case 1:
+		return 1, 0
+	case 2:
+		return 0, 1
+	}
+	return -1, 0
+}
+
+func rollEncounter(rng *rand.Rand, depth int, bestiary *Bestiary.Bestiary) *Bestiary.Encounter {
+	best := bestiary.RollEncounter(rng)
+	for i := 1; i < depth; i++ {
+		if e := bestiary.RollEncounter(rng); threat(bestiary, e) > threat(bestiary, best) {
+			best = e
+		}
+	}
+	return best
+}
+
+func threat(bestiary *Bestiary.Bestiary, encounter *Bestiary.Encounter) int {
+	total := 0
+	for _, id := range encounter.Enemies {
+		if e := bestiary.Get(id); e != nil {
+			total += max(e.Level, 1)
+		}
+	}
+	return total
+}
+
+func rollKind(rng *rand.Rand) RoomKind {
+	total := 0
+	for _, w := range kindWeights {
+		total += w.weight
+	}
+	roll := rng.Intn(total)
+	for _, w := range kindWeights {
+		if roll < w.weight {
+			return w.kind
+		}
+		roll -= w.weight
+	}
+	return RoomEmpty
+}
+
+func (d *Dungeon) inside(x, y int) bool {
+	return x >= 0 && x < Width && y >= 0 && y < Height
+}
+
+func (d *Dungeon) farthest() [2]int {
+	start := [2]int{d.X, d.Y}
+	dist := map[[2]int]int{start: 0}
+	queue := [][2]int{start}
+	best := start
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		if dist[cell] > dist[best] {
+			best = cell
+		}
+		for n := 0; n < 4; n++ {
+			dx, dy := direction(n)
+			next := [2]int{cell[0] + dx, cell[1] + dy}
+			if _, seen := dist[next]; seen || d.Room(next[0], next[1]) == nil {
+				continue
+			}
+			dist[next] = dist[cell] + 1
+			queue = append(queue, next)
+		}
+	}
+	return best
+}
+
+func (d *Dungeon) Room(x, y int) *Room {
+	if !d.inside(x, y) {
+		return nil
+	}
+	return d.Rooms[y][x]
+}
+
+func (d *Dungeon) Current() *Room {
+	return d.Room(d.X, d.Y)
+}
+
+func (d *Dungeon) Known(x, y int) bool {
+	room := d.Room(x, y)
+	if room == nil {
+		return false
+	}
+	if room.Visited {
+		return true
+	}
+	for n := 0; n < 4; n++ {
+		dx, dy := direction(n)
+		if neighbour := d.Room(x+dx, y+dy); neighbour != nil && neighbour.Visited {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Dungeon) Move(dx, dy int) (*Room, error) {
+	room := d.Room(d.X+dx, d.Y+dy)
+	if room == nil {
+		return nil, fmt.Errorf("там глухая стена")
+	}
+	d.prevX, d.prevY = d.X, d.Y
+	d.X, d.Y = d.X+dx, d.Y+dy
+	room.Visited = true
+	return room, nil
+}
+
+func (d *Dungeon) Retreat() {
+	d.X, d.Y = d.prevX, d.prevY
+}
+
+func (d *Dungeon) TrapDamage(maxHP int) int {
+	damage := max(int(float64(maxHP)*TrapShare*(1+0.25*float64(d.Depth-1))), 1)
+	return min(damage, max(d.HP-1, 0))
+}
+
+func (d *Dungeon) RestHeal(maxHP int) int {
+	return min(max(int(float64(maxHP)*RestShare), 1), max(maxHP-d.HP, 0))
+}
+
+func (d *Dungeon) Explored() (visited, total int) {
+	for _, row := range d.Rooms {
+		for _, room := range row {
+			if room == nil {
+				continue
+			}
+			total++
+			if room.Visited {
+				visited++
+			}
+		}
+	}
+	return visited, total
+}
@@ -0,0 +1,170 @@
+package Dungeon
+
+import (
+	"math/rand"
+	"testing"
+
+	"MyGame/Struct/Bestiary"
+)
+
+func generate(t *testing.T, seed int64, depth int) *Dungeon {
+	t.Helper()
+	bestiary, err := Bestiary.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := Generate(rand.New(rand.NewSource(seed)), depth, bestiary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestGenerate(t *testing.T) {
+	for seed := int64(1); seed <= 25; seed++ {
+		d := generate(t, seed, 2)
+		if _, total := d.Explored(); total != RoomCount {
+			t.Fatalf("сид %d: комнат %d, ожидалось %d", seed, total, RoomCount)
+		}
+		if start := d.Current(); start == nil || start.Kind != RoomStart || !start.Visited {
+			t.Fatalf("сид %d: герой стоит не у входа", seed)
+		}
+		exits, reachable := 0, map[[2]int]bool{{d.X, d.Y}: true}
+		queue := [][2]int{{d.X, d.Y}}
+		for len(queue) > 0 {
+			cell := queue[0]
+			queue = queue[1:]
+			for n := 0; n < 4; n++ {
+				dx, dy := direction(n)
+				next := [2]int{cell[0] + dx, cell[1] + dy}
+				if d.Room(next[0], next[1]) != nil && !reachable[next] {
+					reachable[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		for y, row := range d.Rooms {
+			for x, room := range row {
+				if room == nil {
+					continue
+				}
+				if !reachable[[2]int{x, y}] {
+					t.Errorf("сид %d: комната %d,%d недостижима", seed, x, y)
+				}
+				if room.Kind == RoomExit {
+					exits++
+				}
+				if room.Kind == RoomBattle && room.Encounter == "" {
+					t.Errorf("сид %d: в логове %d,%d нет врагов", seed, x, y)
+				}
+			}
+		}
+		if exits != 1 {
+			t.Errorf("сид %d: спусков %d", seed, exits)
+		}
+	}
+}
+
+func TestDeeperLevelsAreMoreDangerous(t *testing.T) {
+	bestiary, err := Bestiary.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := func(depth int) int {
+		sum := 0
+		for seed := int64(1); seed <= 50; seed++ {
+			sum += threat(bestiary, rollEncounter(rand.New(rand.NewSource(seed)), depth, bestiary))
+		}
+		return sum
+	}
+	if shallow, deep := total(1), total(4); deep <= shallow {
+		t.Errorf("угроза на 4-м уровне %d, на 1-м %d", deep, shallow)
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	a, b := generate(t, 99, 1), generate(t, 99, 1)
+	for y := range a.Rooms {
+		for x := range a.Rooms[y] {
+			ra, rb := a.Rooms[y][x], b.Rooms[y][x]
+			if (ra == nil) != (rb == nil) || ra != nil && *ra != *rb {
+				t.Fatalf("комната %d,%d различается", x, y)
+			}
+		}
+	}
+	if _, err := Generate(rand.New(rand.NewSource(1)), 1, Bestiary.New()); err == nil {
+		t.Error("подземелье сгенерировано без встреч")
+	}
+}
+
+func TestTrapAndRest(t *testing.T) {
+	tests := []struct {
+		name  string
+		depth int
+		hp    int
+		maxHP int
+		trap  int
+		rest  int
+	}{
+		{"первый уровень", 1, 100, 100, 15, 0},
+		{"глубже — больнее", 3, 100, 100, 22, 0},
+		{"ловушка не убивает", 1, 5, 100, 4, 50},
+		{"привал не лечит выше максимума", 1, 80, 100, 15, 20},
+		{"при одном HP ловушка безвредна", 1, 1, 100, 0, 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Dungeon{Depth: tt.depth, HP: tt.hp}
+			if got := d.TrapDamage(tt.maxHP); got != tt.trap {
+				t.Errorf("TrapDamage = %d, ожидалось %d", got, tt.trap)
+			}
+			if got := d.RestHeal(tt.maxHP); got != tt.rest {
+				t.Errorf("RestHeal = %d, ожидалось %d", got, tt.rest)
+			}
+		})
+	}
+}
+
+func TestMoveRetreatAndRestore(t *testing.T) {
+	rooms := map[[2]int]*Room{
+		{4, 2}: {Kind: RoomStart, Visited: true, Cleared: true},
+		{5, 2}: {Kind: RoomBattle, Encounter: "goblin"},
+		{6, 2}: {Kind: RoomExit},
+	}
+	d, err := Restore(1, 4, 2, 30, false, rooms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Known(5, 2) || d.Known(6, 2) {
+		t.Errorf("видимость соседей: %v %v", d.Known(5, 2), d.Known(6, 2))
+	}
+	d.Retreat()
+	if d.X != 4 || d.Y != 2 {
+		t.Fatalf("отступление без хода увело в %d,%d", d.X, d.Y)
+	}
+	if _, err := d.Move(0, 1); err == nil {
+		t.Error("прошли сквозь стену")
+	}
+	room, err := d.Move(1, 0)
+	if err != nil || room.Kind != RoomBattle || !room.Visited {
+		t.Fatalf("Move: %v, %+v", err, room)
+	}
+	d.Retreat()
+	if d.X != 4 || d.Y != 2 {
+		t.Errorf("после отступления герой в %d,%d", d.X, d.Y)
+	}
+
+	tests := []struct {
+		name  string
+		x, y  int
+		rooms map[[2]int]*Room
+	}{
+		{"герой вне комнат", 0, 0, rooms},
+		{"комната вне карты", 4, 2, map[[2]int]*Room{{4, 2}: {Kind: RoomStart}, {Width, 0}: {}}},
+	}
+	for _, tt := range tests {
+		if _, err := Restore(1, tt.x, tt.y, 10, false, tt.rooms); err == nil {
+			t.Errorf("%s: ожидалась ошибка", tt.name)
+		}
+	}
+}
@@ -3,12 +3,37 @@ package Equipment
 import (
 	"MyGame/Struct/Item"
 	"fmt"
+	"strings"
 )
 
 type Equipment struct {
 	Slots map[Item.EquipmentSlot]*Item.Item
 }
 
+type ArmorZone struct {
+	Name  string
+	Parts []string
+	Slots []Item.EquipmentSlot
+}
+
+var ArmorZones = []ArmorZone{
+	{Name: "Голова", Parts: []string{"Голова"}, Slots: []Item.EquipmentSlot{Item.SlotHead}},
+	{Name: "Тело", Parts: []string{"Тело"}, Slots: []Item.EquipmentSlot{Item.SlotBody}},
+	{Name: "Руки", Parts: []string{"Правая рука", "Левая рука"}, Slots: []Item.EquipmentSlot{Item.SlotHands}},
+	{Name: "Ноги", Parts: []string{"Правая нога", "Левая нога"}, Slots: []Item.EquipmentSlot{Item.SlotFeet, Item.SlotBoots}},
+}
+
+func ZoneFor(part string) (ArmorZone, bool) {
+	for _, zone := range ArmorZones {
+		for _, p := range zone.Parts {
+			if p == part {
+				return zone, true
+			}
+		}
+	}
+	return ArmorZone{}, false
+}
+
 func NewEquipment() *Equipment {
 	return &Equipment{
 		Slots: make(map[Item.EquipmentSlot]*Item.Item),
@@ -54,8 +79,9 @@ func (e *Equipment) GetItem(slot Item.EquipmentSlot) *Item.Item {
 	return e.Slots[slot]
 }
 
-func (e *Equipment) Display() {
-	fmt.Println("=== ЭКИПИРОВКА ===")
+func (e *Equipment) Summary() string {
+	var b strings.Builder
+	b.WriteString("=== ЭКИПИРОВКА ===\n")
 	slots := e.GetAllEquipmentSlots()
 
 	for _, slot := range slots {
@@ -64,8 +90,9 @@ func (e *Equipment) Display() {
 		if item != nil && item.Template != nil {
 			itemName = fmt.Sprintf("%s (%s)", item.Template.Name, item.GetTypeName())
 		}
-		fmt.Printf("%s: %s\n", e.GetSlotName(slot), itemName)
+		fmt.Fprintf(&b, "%s: %s\n", e.GetSlotName(slot), itemName)
 	}
+	return b.String()
 }
 
 func (e *Equipment) GetSlotName(slot Item.EquipmentSlot) string {
@@ -85,21 +112,127 @@ func (e *Equipment) GetSlotName(slot Item.EquipmentSlot) string {
 func (e *Equipment) GetTotalBonuses() *Item.Item {
 	total := &Item.Item{}
 
-	for _, item := range e.Slots {
-		if item != nil {
-			total.Strength += item.Strength
-			total.Agility += item.Agility
-			total.Intelligence += item.Intelligence
-			total.Attack += item.Attack
-			total.Defense += item.Defense
-			total.Health += item.Health
-			total.Mana += item.Mana
+	for _, slot := range e.GetAllEquipmentSlots() {
+		item := e.Slots[slot]
+		if item == nil || item.IsBroken() {
+			continue
+		}
+		f := item.Condition()
+		total.Strength += scaled(item.Strength, f)
+		total.Agility += scaled(item.Agility, f)
+		total.Intelligence += scaled(item.Intelligence, f)
+		total.Attack += item.Attack * f
+		total.Defense += item.Defense * f
+		total.Health += item.Health * f
+		total.Mana += item.Mana * f
+		total.HealthRegen += item.HealthRegen * f
+		total.ManaRegen += item.ManaRegen * f
+		total.Evasion += item.Evasion * f
+		total.CriticalChance += item.CriticalChance * f
+		total.MagicAmp += item.MagicAmp * f
+		total.Lifesteal += item.Lifesteal * f
+	}
+
+	for _, status := range e.ActiveSets() {
+		for _, bonus := range status.Set.Active(status.Pieces) {
+			total.Strength += bonus.Strength
+			total.Agility += bonus.Agility
+			total.Intelligence += bonus.Intelligence
+			total.Attack += bonus.Attack
+			total.Defense += bonus.Defense
+			total.Health += bonus.Health
+			total.Mana += bonus.Mana
+			total.HealthRegen += bonus.HealthRegen
+			total.ManaRegen += bonus.ManaRegen
+			total.Evasion += bonus.Evasion
+			total.CriticalChance += bonus.CriticalChance
+			total.MagicAmp += bonus.MagicAmp
+			total.Lifesteal += bonus.Lifesteal
+		}
+	}
+
+	return total
+}
+
+type SetStatus struct {
+	Set    *Item.ItemSet
+	Pieces int
+}
+
+func (e *Equipment) ActiveSets() []SetStatus {
+	counts := make(map[*Item.ItemSet]int)
+	order := make([]*Item.ItemSet, 0)
+	for _, slot := range e.GetAllEquipmentSlots() {
+		item := e.Slots[slot]
+		if item == nil || item.IsBroken() {
+			continue
+		}
+		if set := item.Set(); set != nil {
+			if counts[set] == 0 {
+				order = append(order, set)
+			}
+			counts[set]++
 		}
 	}
+	out := make([]SetStatus, 0, len(order))
+	for _, set := range order {
+		out = append(out, SetStatus{Set: set, Pieces: counts[set]})
+	}
+	return out
+}
+
+func (e *Equipment) SetPieces(set *Item.ItemSet) int {
+	if set == nil {
+		return 0
+	}
+	for _, status := range e.ActiveSets() {
+		if status.Set == set {
+			return status.Pieces
+		}
+	}
+	return 0
+}
+
+func scaled(v int, f float32) int {
+	return int(float32(v) * f)
+}
 
+func (e *Equipment) ArmorDefense() float32 {
+	var total float32
+	for _, zone := range ArmorZones {
+		total += e.slotsDefense(zone.Slots)
+	}
 	return total
 }
 
+func (e *Equipment) ZoneArmor(part string) float32 {
+	zone, ok := ZoneFor(part)
+	if !ok {
+		return 0
+	}
+	return e.slotsDefense(zone.Slots)
+}
+
+func (e *Equipment) slotsDefense(slots []Item.EquipmentSlot) float32 {
+	var total float32
+	for _, slot := range slots {
+		if item := e.Slots[slot]; item != nil {
+			total += item.Defense * item.Condition()
+		}
+	}
+	return total
+}
+
+func (e *Equipment) GetAttackSpeedBonus() float32 {
+	var bonus float32
+	for _, slot := range e.GetAllEquipmentSlots() {
+		if item := e.Slots[slot]; item != nil && item.Template != nil && !item.IsBroken() {
+			bonus += item.Template.AttackSpeed
+		}
+	}
+	return bonus
+}
+
 func (e *Equipment) GetAllEquipmentSlots() []Item.EquipmentSlot {
 	return []Item.EquipmentSlot{
 		Item.SlotWeapon,
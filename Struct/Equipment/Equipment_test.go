@@ -0,0 +1,35 @@
+package Equipment
+
+import (
+	"testing"
+
+	"MyGame/Struct/Item"
+)
+
+func TestBonusesFollowCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		durability int
+		factor     float32
+	}{
+		{"целый", 100, 1},
+		{"изношенный", 10, Item.WornStatFactor},
+		{"сломанный", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := Item.CreateItem(31, Item.Common, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			e := NewEquipment()
+			if err := e.Equip(item); err != nil {
+				t.Fatal(err)
+			}
+			item.Durability = tt.durability
+			if got, want := e.GetTotalBonuses().Defense, item.Defense*tt.factor; got != want {
+				t.Errorf("защита %.2f, ожидалось %.2f", got, want)
+			}
+		})
+	}
+}
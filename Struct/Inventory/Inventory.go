@@ -3,6 +3,7 @@ package Inventory
 import (
 	"MyGame/Struct/Item"
 	"fmt"
+	"strings"
 )
 
 type Inventory struct {
@@ -57,6 +58,18 @@ func (inv *Inventory) RemoveItem(itemID int) (*Item.Item, error) {
 	return nil, fmt.Errorf("предмет с ID %d не найден", itemID)
 }
 
+func (inv *Inventory) RemoveAt(index int) (*Item.Item, error) {
+	if index < 0 || index >= len(inv.Items) {
+		return nil, fmt.Errorf("в инвентаре нет ячейки %d", index)
+	}
+	item := inv.Items[index]
+	if item != nil && item.IsEquipped {
+		return nil, fmt.Errorf("нельзя удалить экипированный предмет")
+	}
+	inv.Items = append(inv.Items[:index], inv.Items[index+1:]...)
+	return item, nil
+}
+
 func (inv *Inventory) FindItemByID(itemID int) *Item.Item {
 	for _, item := range inv.Items {
 		if item != nil && item.Template != nil && item.Template.ID == itemID {
@@ -102,13 +115,13 @@ func (inv *Inventory) GetEmptySlots() int {
 	return empty
 }
 
-func (inv *Inventory) Display() {
+func (inv *Inventory) Summary() string {
 	if len(inv.Items) == 0 {
-		fmt.Println("Инвентарь пуст")
-		return
+		return "Инвентарь пуст\n"
 	}
 
-	fmt.Println("=== ИНВЕНТАРЬ ===")
+	var b strings.Builder
+	b.WriteString("=== ИНВЕНТАРЬ ===\n")
 	for i, item := range inv.Items {
 		if item == nil {
 			continue
@@ -118,8 +131,8 @@ func (inv *Inventory) Display() {
 		if item.IsEquipped {
 			equipped = " [Экипировано]"
 		}
-		fmt.Printf("%d. %s%s\n", i+1, item.Template.Name, equipped)
-		fmt.Printf("   %s\n", item.Template.Description)
+		fmt.Fprintf(&b, "%d. %s%s\n", i+1, item.Template.Name, equipped)
+		fmt.Fprintf(&b, "   %s\n", item.Template.Description)
 
 		stats := make([]string, 0)
 		if item.Strength > 0 {
@@ -136,10 +149,11 @@ func (inv *Inventory) Display() {
 		}
 
 		if len(stats) > 0 {
-			fmt.Printf("   %s\n", joinStats(stats))
+			fmt.Fprintf(&b, "   %s\n", joinStats(stats))
 		}
 	}
-	fmt.Printf("Свободно мест: %d/%d\n", inv.GetEmptySlots(), inv.Capacity)
+	fmt.Fprintf(&b, "Свободно мест: %d/%d\n", inv.GetEmptySlots(), inv.Capacity)
+	return b.String()
 }
 
 func (inv *Inventory) TransferItem(itemID int, targetInventory *Inventory) error {
@@ -1,6 +1,18 @@
 package Item
 
-import "fmt"
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed sets.json
+var embeddedSets []byte
 
 type ItemType int
 type EquipmentSlot int
@@ -12,6 +24,7 @@ const (
 	Armor
 	Accessory
 	Consumable
+	Material
 )
 
 const (
@@ -71,12 +84,92 @@ type Item struct {
 	Health       float32
 	Mana         float32
 
+	HealthRegen    float32
+	ManaRegen      float32
+	Evasion        float32
+	CriticalChance float32
+	MagicAmp       float32
+	Lifesteal      float32
+
 	Durability    int
 	MaxDurability int
 	Price         int
 	IsEquipped    bool
+	Affixes       []*Affix
+}
+
+type Affix struct {
+	ID             string
+	Name           string
+	Suffix         bool
+	Health         float32
+	HealthRegen    float32
+	ManaRegen      float32
+	Evasion        float32
+	CriticalChance float32
+	MagicAmp       float32
+	Lifesteal      float32
+}
+
+var affixTable = []*Affix{
+	{ID: "flaming", Name: "Пылающий", CriticalChance: 0.03},
+	{ID: "nimble", Name: "Проворный", Evasion: 0.03},
+	{ID: "vital", Name: "Живучий", HealthRegen: 1.0},
+	{ID: "arcane", Name: "Чародейский", MagicAmp: 0.05, ManaRegen: 0.5},
+	{ID: "sturdy", Name: "Крепкий", Health: 15},
+	{ID: "vampire", Name: "Вампира", Suffix: true, Lifesteal: 0.04},
+	{ID: "hawk", Name: "Ястреба", Suffix: true, CriticalChance: 0.04},
+	{ID: "troll", Name: "Тролля", Suffix: true, HealthRegen: 1.5},
+	{ID: "shadow", Name: "Тени", Suffix: true, Evasion: 0.04},
+	{ID: "sage", Name: "Мудреца", Suffix: true, ManaRegen: 1.0, MagicAmp: 0.03},
+}
+
+var affixCounts = map[Rarity]int{
+	Common:    0,
+	Uncommon:  1,
+	Rare:      1,
+	Epic:      2,
+	Legendary: 2,
+	Mythic:    2,
+}
+
+const affixPriceShare = 0.15
+
+type SetBonus struct {
+	Pieces         int     `json:"pieces"`
+	Strength       int     `json:"strength"`
+	Agility        int     `json:"agility"`
+	Intelligence   int     `json:"intelligence"`
+	Attack         float32 `json:"attack"`
+	Defense        float32 `json:"defense"`
+	Health         float32 `json:"health"`
+	Mana           float32 `json:"mana"`
+	HealthRegen    float32 `json:"health_regen"`
+	ManaRegen      float32 `json:"mana_regen"`
+	Evasion        float32 `json:"evasion"`
+	CriticalChance float32 `json:"critical_chance"`
+	MagicAmp       float32 `json:"magic_amp"`
+	Lifesteal      float32 `json:"lifesteal"`
+}
+
+type ItemSet struct {
+	ID      string     `json:"id"`
+	Name    string     `json:"name"`
+	Pieces  []int      `json:"pieces"`
+	Bonuses []SetBonus `json:"bonuses"`
 }
 
+type setsFile struct {
+	Sets []*ItemSet `json:"sets"`
+}
+
+var (
+	setsOnce  sync.Once
+	itemSets  []*ItemSet
+	setsByID  map[int]*ItemSet
+	setsError error
+)
+
 var rarityMultipliers = map[Rarity]float64{
 	Common:    1.0,
 	Uncommon:  1.3,
@@ -88,6 +181,29 @@ var rarityMultipliers = map[Rarity]float64{
 
 const levelMultiplierPerLevel = 0.1
 
+var rarityWeights = map[Rarity]float64{
+	Common:    60,
+	Uncommon:  25,
+	Rare:      10,
+	Epic:      4,
+	Legendary: 0.8,
+	Mythic:    0.2,
+}
+
+const rarityShiftPerStep = 0.35
+
+const (
+	WornDurabilityShare = 0.25
+	WornStatFactor      = 0.5
+)
+
+const (
+	ReturnScrollID = 20
+	HealingSalveID = 29
+	IronIngotID    = 35
+	ArcaneDustID   = 36
+)
+
 var rarityNames = map[Rarity]string{
 	Common:    "Обычный",
 	Uncommon:  "Необычный",
@@ -103,6 +219,7 @@ var typeNames = map[ItemType]string{
 	Armor:      "Броня",
 	Accessory:  "Аксессуар",
 	Consumable: "Расходный",
+	Material:   "Материал",
 }
 
 var itemTemplates = map[int]*ItemTemplate{
@@ -145,6 +262,7 @@ var itemTemplates = map[int]*ItemTemplate{
 		Type:        Accessory,
 		Slot:        SlotAccessory,
 		BaseDefense: 3.0,
+		Evasion:     0.05,
 		Description: "Защищает от неожиданных атак",
 	},
 	22: {
@@ -165,13 +283,15 @@ var itemTemplates = map[int]*ItemTemplate{
 		Description: "Временно увеличивает атаку",
 	},
 	24: {
-		ID:          24,
-		Name:        "Быстрый клинок",
-		Type:        Weapon,
-		Slot:        SlotWeapon,
-		BaseAttack:  3.0,
-		BaseAgility: 4,
-		Description: "Лёгкий клинок, повышает ловкость",
+		ID:             24,
+		Name:           "Быстрый клинок",
+		Type:           Weapon,
+		Slot:           SlotWeapon,
+		BaseAttack:     3.0,
+		BaseAgility:    4,
+		AttackSpeed:    0.3,
+		CriticalChance: 0.05,
+		Description:    "Лёгкий клинок, повышает ловкость",
 	},
 	25: {
 		ID:           25,
@@ -180,6 +300,7 @@ var itemTemplates = map[int]*ItemTemplate{
 		Slot:         SlotWeapon,
 		BaseAttack:   7.0,
 		BaseStrength: 1,
+		AttackSpeed:  -0.25,
 		Description:  "Мощный удар, но медленный",
 	},
 	26: {
@@ -191,6 +312,199 @@ var itemTemplates = map[int]*ItemTemplate{
 		BaseDefense: 2.0,
 		Description: "Баланс атаки и защиты",
 	},
+	27: {
+		ID:          27,
+		Name:        "Клинок кровопийцы",
+		Type:        Weapon,
+		Slot:        SlotWeapon,
+		BaseAttack:  4.0,
+		Lifesteal:   0.15,
+		Description: "Возвращает владельцу часть нанесённого урона",
+	},
+	28: {
+		ID:               28,
+		Name:             "Амулет чародея",
+		Type:             Accessory,
+		Slot:             SlotAccessory,
+		BaseIntelligence: 3,
+		BaseMana:         20.0,
+		ManaRegen:        2.0,
+		MagicAmp:         0.2,
+		Description:      "Усиливает лечение и восстановление",
+	},
+	29: {
+		ID:          29,
+		Name:        "Целебная мазь",
+		Type:        Consumable,
+		Slot:        SlotNone,
+		Description: "Заживляет травмы, полученные в бою",
+	},
+	30: {
+		ID:          30,
+		Name:        "Кожаный шлем",
+		Type:        Armor,
+		Slot:        SlotHead,
+		BaseDefense: 4.0,
+		Description: "Защищает голову от оглушающих ударов",
+	},
+	31: {
+		ID:          31,
+		Name:        "Кольчуга",
+		Type:        Armor,
+		Slot:        SlotBody,
+		BaseDefense: 6.0,
+		BaseHealth:  10.0,
+		Description: "Надёжно прикрывает туловище",
+	},
+	32: {
+		ID:          32,
+		Name:        "Латные перчатки",
+		Type:        Armor,
+		Slot:        SlotHands,
+		BaseDefense: 3.0,
+		Description: "Берегут руки, но не мешают держать оружие",
+	},
+	33: {
+		ID:          33,
+		Name:        "Поножи",
+		Type:        Armor,
+		Slot:        SlotFeet,
+		BaseDefense: 3.0,
+		Description: "Закрывают бёдра и колени",
+	},
+	34: {
+		ID:          34,
+		Name:        "Сапоги следопыта",
+		Type:        Armor,
+		Slot:        SlotBoots,
+		BaseDefense: 2.0,
+		BaseAgility: 1,
+		Description: "Прочная обувь, в которой легко двигаться",
+	},
+	35: {
+		ID:          35,
+		Name:        "Железный слиток",
+		Type:        Material,
+		Slot:        SlotNone,
+		Description: "Основной материал мастерской для улучшения снаряжения",
+	},
+	36: {
+		ID:          36,
+		Name:        "Магическая пыль",
+		Type:        Material,
+		Slot:        SlotNone,
+		Description: "Нужна для улучшения редких вещей и по многим рецептам",
+	},
+}
+
+func loadSets() {
+	setsByID = make(map[int]*ItemSet)
+	var file setsFile
+	if err := json.Unmarshal(embeddedSets, &file); err != nil {
+		setsError = fmt.Errorf("ошибка разбора комплектов: %w", err)
+		return
+	}
+	for _, set := range file.Sets {
+		if err := set.validate(); err != nil {
+			setsError = err
+			return
+		}
+		itemSets = append(itemSets, set)
+		for _, id := range set.Pieces {
+			setsByID[id] = set
+		}
+	}
+}
+
+func (s *ItemSet) validate() error {
+	if s == nil || strings.TrimSpace(s.ID) == "" {
+		return fmt.Errorf("у комплекта не задан id")
+	}
+	if len(s.Pieces) < 2 {
+		return fmt.Errorf("в комплекте '%s' меньше двух предметов", s.ID)
+	}
+	for _, id := range s.Pieces {
+		if _, ok := itemTemplates[id]; !ok {
+			return fmt.Errorf("в комплекте '%s' неизвестный предмет %d", s.ID, id)
+		}
+		if other := setsByID[id]; other != nil {
+			return fmt.Errorf("предмет %d входит сразу в комплекты '%s' и '%s'", id, other.ID, s.ID)
+		}
+	}
+	sort.Slice(s.Bonuses, func(a, b int) bool { return s.Bonuses[a].Pieces < s.Bonuses[b].Pieces })
+	for _, bonus := range s.Bonuses {
+		if bonus.Pieces < 2 || bonus.Pieces > len(s.Pieces) {
+			return fmt.Errorf("комплект '%s': бонус за %d предм. недостижим", s.ID, bonus.Pieces)
+		}
+	}
+	if s.Name == "" {
+		s.Name = s.ID
+	}
+	return nil
+}
+
+func Sets() []*ItemSet {
+	setsOnce.Do(loadSets)
+	return itemSets
+}
+
+func SetsError() error {
+	setsOnce.Do(loadSets)
+	return setsError
+}
+
+func SetOf(templateID int) *ItemSet {
+	setsOnce.Do(loadSets)
+	return setsByID[templateID]
+}
+
+func (s *ItemSet) Active(pieces int) []SetBonus {
+	out := make([]SetBonus, 0, len(s.Bonuses))
+	for _, bonus := range s.Bonuses {
+		if pieces >= bonus.Pieces {
+			out = append(out, bonus)
+		}
+	}
+	return out
+}
+
+func (b SetBonus) String() string {
+	parts := make([]string, 0)
+	add := func(ok bool, format string, v any) {
+		if ok {
+			parts = append(parts, fmt.Sprintf(format, v))
+		}
+	}
+	add(b.Strength != 0, "Сила +%d", b.Strength)
+	add(b.Agility != 0, "Ловкость +%d", b.Agility)
+	add(b.Intelligence != 0, "Интеллект +%d", b.Intelligence)
+	add(b.Attack != 0, "Атака +%.1f", b.Attack)
+	add(b.Defense != 0, "Защита +%.1f", b.Defense)
+	add(b.Health != 0, "Здоровье +%.0f", b.Health)
+	add(b.Mana != 0, "Мана +%.0f", b.Mana)
+	add(b.HealthRegen != 0, "Регенерация здоровья +%.1f", b.HealthRegen)
+	add(b.ManaRegen != 0, "Регенерация маны +%.1f", b.ManaRegen)
+	add(b.Evasion != 0, "Уклонение +%.0f%%", b.Evasion*100)
+	add(b.CriticalChance != 0, "Крит +%.0f%%", b.CriticalChance*100)
+	add(b.MagicAmp != 0, "Усиление магии +%.0f%%", b.MagicAmp*100)
+	add(b.Lifesteal != 0, "Вампиризм +%.0f%%", b.Lifesteal*100)
+	return strings.Join(parts, ", ")
+}
+
+func (i *Item) Set() *ItemSet {
+	if i.Template == nil {
+		return nil
+	}
+	return SetOf(i.Template.ID)
+}
+
+func TemplateIDs() []int {
+	ids := make([]int, 0, len(itemTemplates))
+	for id := range itemTemplates {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
 }
 
 func CreateItem(templateID int, rarity Rarity, level int) (*Item, error) {
@@ -199,48 +513,204 @@ func CreateItem(templateID int, rarity Rarity, level int) (*Item, error) {
 		return nil, fmt.Errorf("шаблон предмета с ID %d не найден", templateID)
 	}
 
+	item := &Item{
+		Template:      template,
+		Durability:    100,
+		MaxDurability: 100,
+		IsEquipped:    false,
+	}
+	if err := item.Rescale(rarity, level); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (i *Item) Rescale(rarity Rarity, level int) error {
+	if i.Template == nil {
+		return fmt.Errorf("у предмета нет шаблона")
+	}
+
 	if level < 1 {
-		return nil, fmt.Errorf("уровень предмета должен быть положительным")
+		return fmt.Errorf("уровень предмета должен быть положительным")
 	}
 
 	if rarity < Common || rarity > Mythic {
-		return nil, fmt.Errorf("некорректная редкость предмета")
+		return fmt.Errorf("некорректная редкость предмета")
 	}
 
+	template := i.Template
+	i.Rarity = rarity
+	i.Level = level
+
 	rarityMultiplier := rarityMultipliers[rarity]
 	levelMultiplier := 1.0 + (float64(level-1) * levelMultiplierPerLevel)
 
-	item := &Item{
-		Template:      template,
-		Rarity:        rarity,
-		Level:         level,
-		Durability:    100,
-		MaxDurability: 100,
-		IsEquipped:    false,
-	}
-
 	totalMultiplier := rarityMultiplier * levelMultiplier
 
-	item.Strength = int(float64(template.BaseStrength) * totalMultiplier)
-	item.Agility = int(float64(template.BaseAgility) * totalMultiplier)
-	item.Intelligence = int(float64(template.BaseIntelligence) * totalMultiplier)
-	item.Attack = float32(float64(template.BaseAttack) * totalMultiplier)
-	item.Defense = float32(float64(template.BaseDefense) * totalMultiplier)
-	item.Health = float32(float64(template.BaseHealth) * totalMultiplier)
-	item.Mana = float32(float64(template.BaseMana) * totalMultiplier)
+	i.Strength = int(float64(template.BaseStrength) * totalMultiplier)
+	i.Agility = int(float64(template.BaseAgility) * totalMultiplier)
+	i.Intelligence = int(float64(template.BaseIntelligence) * totalMultiplier)
+	i.Attack = float32(float64(template.BaseAttack) * totalMultiplier)
+	i.Defense = float32(float64(template.BaseDefense) * totalMultiplier)
+	i.Health = float32(float64(template.BaseHealth) * totalMultiplier)
+	i.Mana = float32(float64(template.BaseMana) * totalMultiplier)
+	i.HealthRegen = float32(float64(template.HealthRegen) * totalMultiplier)
+	i.ManaRegen = float32(float64(template.ManaRegen) * totalMultiplier)
+	i.Evasion = float32(float64(template.Evasion) * totalMultiplier)
+	i.CriticalChance = float32(float64(template.CriticalChance) * totalMultiplier)
+	i.MagicAmp = float32(float64(template.MagicAmp) * totalMultiplier)
+	i.Lifesteal = float32(float64(template.Lifesteal) * totalMultiplier)
+
+	for _, affix := range i.Affixes {
+		f := float32(levelMultiplier)
+		i.Health += affix.Health * f
+		i.HealthRegen += affix.HealthRegen * f
+		i.ManaRegen += affix.ManaRegen * f
+		i.Evasion += affix.Evasion * f
+		i.CriticalChance += affix.CriticalChance * f
+		i.MagicAmp += affix.MagicAmp * f
+		i.Lifesteal += affix.Lifesteal * f
+	}
 
 	basePrice := 10 + (level * 5)
-	item.Price = int(float64(basePrice) * totalMultiplier)
+	i.Price = int(float64(basePrice) * totalMultiplier * (1 + affixPriceShare*float64(len(i.Affixes))))
+
+	return nil
+}
 
+func AffixByID(id string) *Affix {
+	for _, affix := range affixTable {
+		if affix.ID == id {
+			return affix
+		}
+	}
+	return nil
+}
+
+func (a *Affix) String() string {
+	return SetBonus{
+		Health:         a.Health,
+		HealthRegen:    a.HealthRegen,
+		ManaRegen:      a.ManaRegen,
+		Evasion:        a.Evasion,
+		CriticalChance: a.CriticalChance,
+		MagicAmp:       a.MagicAmp,
+		Lifesteal:      a.Lifesteal,
+	}.String()
+}
+
+func GenerateItem(rng *rand.Rand, templateID int, rarity Rarity, level int) (*Item, error) {
+	item, err := CreateItem(templateID, rarity, level)
+	if err != nil {
+		return nil, err
+	}
+	if item.Template.Slot == SlotNone || affixCounts[rarity] == 0 {
+		return item, nil
+	}
+	var prefixes, suffixes []*Affix
+	for _, affix := range affixTable {
+		if affix.Suffix {
+			suffixes = append(suffixes, affix)
+		} else {
+			prefixes = append(prefixes, affix)
+		}
+	}
+	pools := [][]*Affix{prefixes, suffixes}
+	rng.Shuffle(len(pools), func(a, b int) { pools[a], pools[b] = pools[b], pools[a] })
+	affixes := make([]*Affix, 0, affixCounts[rarity])
+	for _, pool := range pools[:affixCounts[rarity]] {
+		affixes = append(affixes, pool[rng.Intn(len(pool))])
+	}
+	if err := item.SetAffixes(affixes); err != nil {
+		return nil, err
+	}
 	return item, nil
 }
 
+func (i *Item) SetAffixes(affixes []*Affix) error {
+	prefix, suffix := 0, 0
+	for _, affix := range affixes {
+		if affix == nil {
+			return fmt.Errorf("неизвестное свойство предмета")
+		}
+		if affix.Suffix {
+			suffix++
+		} else {
+			prefix++
+		}
+	}
+	if prefix > 1 || suffix > 1 {
+		return fmt.Errorf("у предмета может быть не больше одной приставки и одного окончания")
+	}
+	i.Affixes = affixes
+	return i.Rescale(i.Rarity, i.Level)
+}
+
+func (i *Item) SetAffixIDs(ids []string) error {
+	affixes := make([]*Affix, 0, len(ids))
+	for _, id := range ids {
+		affix := AffixByID(id)
+		if affix == nil {
+			return fmt.Errorf("неизвестное свойство предмета '%s'", id)
+		}
+		affixes = append(affixes, affix)
+	}
+	return i.SetAffixes(affixes)
+}
+
+func (i *Item) AffixIDs() []string {
+	if len(i.Affixes) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(i.Affixes))
+	for _, affix := range i.Affixes {
+		ids = append(ids, affix.ID)
+	}
+	return ids
+}
+
+func (r Rarity) String() string {
+	if name, ok := rarityNames[r]; ok {
+		return name
+	}
+	return "Неизвестно"
+}
+
+func RollRarity(rng *rand.Rand, shift int) Rarity {
+	boost := 1 + rarityShiftPerStep*float64(max(shift, 0))
+	weights := make([]float64, Mythic+1)
+	total := 0.0
+	for r := Common; r <= Mythic; r++ {
+		weights[r] = rarityWeights[r] * math.Pow(boost, float64(r))
+		total += weights[r]
+	}
+	roll := rng.Float64() * total
+	for r := Common; r <= Mythic; r++ {
+		if roll < weights[r] {
+			return r
+		}
+		roll -= weights[r]
+	}
+	return Common
+}
+
 func (i *Item) GetFullName() string {
 	if i.Template == nil {
 		return "Неизвестный предмет"
 	}
-	rarityName := rarityNames[i.Rarity]
-	return fmt.Sprintf("%s %s", rarityName, i.Template.Name)
+	parts := []string{rarityNames[i.Rarity]}
+	for _, affix := range i.Affixes {
+		if !affix.Suffix {
+			parts = append(parts, affix.Name)
+		}
+	}
+	parts = append(parts, i.Template.Name)
+	for _, affix := range i.Affixes {
+		if affix.Suffix {
+			parts = append(parts, affix.Name)
+		}
+	}
+	return strings.Join(parts, " ")
 }
 
 func (i *Item) GetTypeName() string {
@@ -250,7 +720,7 @@ func (i *Item) GetTypeName() string {
 	return typeNames[i.Template.Type]
 }
 
-func (i *Item) GetDetailedDescription() string {
+func (i *Item) GetDetailedDescription(setPieces int) string {
 	if i.Template == nil {
 		return "Неизвестный предмет"
 	}
@@ -283,23 +753,54 @@ func (i *Item) GetDetailedDescription() string {
 	if i.Mana != 0 {
 		desc += fmt.Sprintf("Мана: +%.1f\n", i.Mana)
 	}
-	if i.Template.HealthRegen != 0 {
-		desc += fmt.Sprintf("Регенерация здоровья: +%.1f/сек\n", i.Template.HealthRegen)
+	if i.HealthRegen != 0 {
+		desc += fmt.Sprintf("Регенерация здоровья: +%.1f/раунд\n", i.HealthRegen)
+	}
+	if i.ManaRegen != 0 {
+		desc += fmt.Sprintf("Регенерация маны: +%.1f/раунд\n", i.ManaRegen)
 	}
-	if i.Template.ManaRegen != 0 {
-		desc += fmt.Sprintf("Регенерация маны: +%.1f/сек\n", i.Template.ManaRegen)
+	if i.Template.AttackSpeed != 0 {
+		desc += fmt.Sprintf("Скорость атаки: %+.0f%%\n", i.Template.AttackSpeed*100)
 	}
-	if i.Template.CriticalChance != 0 {
-		desc += fmt.Sprintf("Шанс критического удара: +%.1f%%\n", i.Template.CriticalChance*100)
+	if i.CriticalChance != 0 {
+		desc += fmt.Sprintf("Шанс критического удара: +%.1f%%\n", i.CriticalChance*100)
 	}
-	if i.Template.Lifesteal != 0 {
-		desc += fmt.Sprintf("Вампиризм: +%.1f%%\n", i.Template.Lifesteal*100)
+	if i.Evasion != 0 {
+		desc += fmt.Sprintf("Уклонение: +%.1f%%\n", i.Evasion*100)
+	}
+	if i.Lifesteal != 0 {
+		desc += fmt.Sprintf("Вампиризм: +%.1f%%\n", i.Lifesteal*100)
+	}
+	if i.MagicAmp != 0 {
+		desc += fmt.Sprintf("Усиление магии: +%.1f%%\n", i.MagicAmp*100)
+	}
+
+	for _, affix := range i.Affixes {
+		desc += fmt.Sprintf("Свойство «%s»: %s\n", affix.Name, affix)
 	}
 
 	desc += fmt.Sprintf("Уровень: %d\n", i.Level)
-	desc += fmt.Sprintf("Прочность: %d/%d\n", i.Durability, i.MaxDurability)
+	desc += fmt.Sprintf("Прочность: %d/%d", i.Durability, i.MaxDurability)
+	switch {
+	case i.IsBroken():
+		desc += " (сломан, бонусы не действуют)"
+	case i.IsWorn():
+		desc += fmt.Sprintf(" (изношен, бонусы ×%.1f)", WornStatFactor)
+	}
+	desc += "\n"
 	desc += fmt.Sprintf("Цена: %d золотых\n", i.Price)
 
+	if set := i.Set(); set != nil {
+		desc += fmt.Sprintf("Комплект «%s»: надето %d/%d\n", set.Name, setPieces, len(set.Pieces))
+		for _, bonus := range set.Bonuses {
+			mark := "○"
+			if setPieces >= bonus.Pieces {
+				mark = "●"
+			}
+			desc += fmt.Sprintf("  %s %d предм.: %s\n", mark, bonus.Pieces, bonus)
+		}
+	}
+
 	if i.IsEquipped {
 		desc += "Состояние: Экипировано\n"
 	}
@@ -309,6 +810,40 @@ func (i *Item) GetDetailedDescription() string {
 	return desc
 }
 
+func (i *Item) IsBroken() bool {
+	return i.MaxDurability > 0 && i.Durability <= 0
+}
+
+func (i *Item) IsWorn() bool {
+	return i.MaxDurability > 0 && float64(i.Durability) < WornDurabilityShare*float64(i.MaxDurability)
+}
+
+func (i *Item) Condition() float32 {
+	switch {
+	case i.IsBroken():
+		return 0
+	case i.IsWorn():
+		return WornStatFactor
+	}
+	return 1
+}
+
+func (i *Item) Wear(amount int) {
+	i.Durability = max(i.Durability-amount, 0)
+}
+
+func (i *Item) RepairCost() int {
+	missing := i.MaxDurability - i.Durability
+	if missing <= 0 {
+		return 0
+	}
+	return max(1, int(math.Ceil(float64(missing*i.Price)/float64(i.MaxDurability))))
+}
+
+func (i *Item) Repair() {
+	i.Durability = i.MaxDurability
+}
+
 func (i *Item) GetSlotName() string {
 	if i.Template == nil {
 		return "Неизвестно"
@@ -364,11 +899,11 @@ func (i *Item) Use() (map[string]float32, error) {
 		effects["defense"] = i.Defense
 	}
 
-	if i.Template.HealthRegen != 0 {
-		effects["health_regen"] = i.Template.HealthRegen
+	if i.HealthRegen != 0 {
+		effects["health_regen"] = i.HealthRegen
 	}
-	if i.Template.ManaRegen != 0 {
-		effects["mana_regen"] = i.Template.ManaRegen
+	if i.ManaRegen != 0 {
+		effects["mana_regen"] = i.ManaRegen
 	}
 
 	switch i.Template.Type {
@@ -397,6 +932,22 @@ func CreatePvPSword(templateID int) *Item {
 	return item
 }
 
+func CreateReturnScroll() *Item {
+	item, err := CreateItem(ReturnScrollID, Common, 1)
+	if err != nil {
+		return nil
+	}
+	return item
+}
+
+func CreateHealingSalve() *Item {
+	item, err := CreateItem(HealingSalveID, Common, 1)
+	if err != nil {
+		return nil
+	}
+	return item
+}
+
 func CreateHealthPotion() *Item {
 	item, err := CreateItem(19, Common, 1)
 	if err != nil {
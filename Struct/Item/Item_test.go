@@ -0,0 +1,60 @@
+package Item
+
+import "testing"
+
+func TestDurabilityCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		durability int
+		max        int
+		worn       bool
+		broken     bool
+		condition  float32
+	}{
+		{"новый", 100, 100, false, false, 1},
+		{"на границе износа", 25, 100, false, false, 1},
+		{"изношен", 24, 100, true, false, WornStatFactor},
+		{"почти сломан", 1, 100, true, false, WornStatFactor},
+		{"сломан", 0, 100, true, true, 0},
+		{"без прочности", 0, 0, false, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Item{Durability: tt.durability, MaxDurability: tt.max}
+			if i.IsWorn() != tt.worn || i.IsBroken() != tt.broken || i.Condition() != tt.condition {
+				t.Errorf("изношен %v, сломан %v, состояние %v; ожидалось %v, %v, %v",
+					i.IsWorn(), i.IsBroken(), i.Condition(), tt.worn, tt.broken, tt.condition)
+			}
+		})
+	}
+}
+
+func TestWearAndRepair(t *testing.T) {
+	tests := []struct {
+		name       string
+		durability int
+		wear       int
+		price      int
+		wantDur    int
+		wantCost   int
+	}{
+		{"без износа", 100, 0, 80, 100, 0},
+		{"немного потрёпан", 100, 10, 80, 90, 8},
+		{"дробная цена округляется вверх", 100, 1, 50, 99, 1},
+		{"износ не уходит ниже нуля", 5, 10, 80, 0, 80},
+		{"дешёвый предмет стоит минимум 1", 100, 1, 0, 99, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Item{Durability: tt.durability, MaxDurability: 100, Price: tt.price}
+			i.Wear(tt.wear)
+			if i.Durability != tt.wantDur || i.RepairCost() != tt.wantCost {
+				t.Fatalf("прочность %d, ремонт %d; ожидалось %d и %d", i.Durability, i.RepairCost(), tt.wantDur, tt.wantCost)
+			}
+			i.Repair()
+			if i.Durability != i.MaxDurability || i.RepairCost() != 0 {
+				t.Errorf("после ремонта прочность %d, цена %d", i.Durability, i.RepairCost())
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package Quest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"MyGame/Struct/Item"
+)
+
+//go:embed quests.json
+var embeddedQuests []byte
+
+type Kind string
+
+const (
+	KindDefeat  Kind = "defeat"
+	KindCollect Kind = "collect"
+	KindReach   Kind = "reach"
+)
+
+type Objective struct {
+	Kind       Kind   `json:"kind"`
+	Target     string `json:"target"`
+	TemplateID int    `json:"template_id"`
+	Count      int    `json:"count"`
+	Text       string `json:"text"`
+}
+
+type Reward struct {
+	XP     int         `json:"xp"`
+	Gold   int         `json:"gold"`
+	Items  []int       `json:"items"`
+	Rarity Item.Rarity `json:"rarity"`
+}
+
+type Quest struct {
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Objectives  []Objective `json:"objectives"`
+	Reward      Reward      `json:"reward"`
+}
+
+type questsFile struct {
+	Quests []*Quest `json:"quests"`
+}
+
+type Holdings func(templateID int) int
+
+type Journal struct {
+	Progress  map[string][]int
+	TurnedIn  map[string]bool
+	Announced map[string]bool
+}
+
+func Load(known func(o Objective) bool) ([]*Quest, error) {
+	var file questsFile
+	if err := json.Unmarshal(embeddedQuests, &file); err != nil {
+		return nil, fmt.Errorf("ошибка разбора заданий: %w", err)
+	}
+	seen := make(map[string]bool)
+	for _, q := range file.Quests {
+		if err := q.validate(known); err != nil {
+			return nil, err
+		}
+		if seen[q.ID] {
+			return nil, fmt.Errorf("задание '%s' повторяется", q.ID)
+		}
+		seen[q.ID] = true
+	}
+	return file.Quests, nil
+}
+
+func (q *Quest) validate(known func(o Objective) bool) error {
+	if q == nil || strings.TrimSpace(q.ID) == "" {
+		return fmt.Errorf("у задания не задан id")
+	}
+	if len(q.Objectives) == 0 {
+		return fmt.Errorf("у задания '%s' нет целей", q.ID)
+	}
+	for _, o := range q.Objectives {
+		switch o.Kind {
+		case KindDefeat, KindCollect, KindReach:
+		default:
+			return fmt.Errorf("задание '%s': неизвестный тип цели '%s'", q.ID, o.Kind)
+		}
+		if o.Count <= 0 {
+			return fmt.Errorf("задание '%s': количество для цели '%s' должно быть положительным", q.ID, o.Text)
+		}
+		if known != nil && !known(o) {
+			return fmt.Errorf("задание '%s': неизвестная цель '%s'", q.ID, o.Text)
+		}
+	}
+	if q.Reward.XP < 0 || q.Reward.Gold < 0 {
+		return fmt.Errorf("задание '%s': отрицательная награда", q.ID)
+	}
+	for _, id := range q.Reward.Items {
+		if _, err := Item.CreateItem(id, q.Reward.Rarity, 1); err != nil {
+			return fmt.Errorf("задание '%s': %w", q.ID, err)
+		}
+	}
+	return nil
+}
+
+func (o Objective) Matches(kind Kind, target string, templateID int) bool {
+	if o.Kind != kind {
+		return false
+	}
+	if kind == KindCollect {
+		return o.TemplateID == templateID
+	}
+	return o.Target == target
+}
+
+func NewJournal() *Journal {
+	return &Journal{Progress: make(map[string][]int), TurnedIn: make(map[string]bool), Announced: make(map[string]bool)}
+}
+
+func Restore(progress map[string][]int, turnedIn, announced []string) *Journal {
+	j := NewJournal()
+	for id, counts := range progress {
+		j.Progress[id] = append([]int(nil), counts...)
+	}
+	for _, id := range turnedIn {
+		j.TurnedIn[id] = true
+	}
+	for _, id := range announced {
+		j.Announced[id] = true
+	}
+	return j
+}
+
+func (j *Journal) Count(q *Quest, objective int, have Holdings) int {
+	if o := q.Objectives[objective]; o.Kind == KindCollect {
+		if have == nil {
+			return 0
+		}
+		return min(have(o.TemplateID), o.Count)
+	}
+	counts := j.Progress[q.ID]
+	if objective >= len(counts) {
+		return 0
+	}
+	return min(counts[objective], q.Objectives[objective].Count)
+}
+
+func (j *Journal) Record(quests []*Quest, kind Kind, target string, amount int, have Holdings) []*Quest {
+	var completed []*Quest
+	if kind == KindCollect {
+		return nil
+	}
+	for _, q := range quests {
+		if j.TurnedIn[q.ID] || j.Complete(q, have) {
+			continue
+		}
+		changed := false
+		for i, o := range q.Objectives {
+			if !o.Matches(kind, target, 0) || j.Count(q, i, have) >= o.Count {
+				continue
+			}
+			counts := j.Progress[q.ID]
+			for len(counts) < len(q.Objectives) {
+				counts = append(counts, 0)
+			}
+			counts[i] = min(counts[i]+amount, o.Count)
+			j.Progress[q.ID] = counts
+			changed = true
+		}
+		if changed && !j.Announced[q.ID] && j.Complete(q, have) {
+			j.Announced[q.ID] = true
+			completed = append(completed, q)
+		}
+	}
+	return completed
+}
+
+func (j *Journal) Collected(quests []*Quest, templateID int, have Holdings) []*Quest {
+	var completed []*Quest
+	for _, q := range quests {
+		if j.TurnedIn[q.ID] || j.Announced[q.ID] || !j.Complete(q, have) {
+			continue
+		}
+		for _, o := range q.Objectives {
+			if o.Matches(KindCollect, "", templateID) {
+				j.Announced[q.ID] = true
+				completed = append(completed, q)
+				break
+			}
+		}
+	}
+	return completed
+}
+
+func (j *Journal) Complete(q *Quest, have Holdings) bool {
+	for i, o := range q.Objectives {
+		if j.Count(q, i, have) < o.Count {
+			return false
+		}
+	}
+	return true
+}
+
+func (j *Journal) CanTurnIn(q *Quest, have Holdings) error {
+	if j.TurnedIn[q.ID] {
+		return fmt.Errorf("награда за задание '%s' уже получена", q.Title)
+	}
+	if !j.Complete(q, have) {
+		return fmt.Errorf("задание '%s' ещё не выполнено", q.Title)
+	}
+	return nil
+}
+
+func (j *Journal) TurnIn(q *Quest) error {
+	if j.TurnedIn[q.ID] {
+		return fmt.Errorf("награда за задание '%s' уже получена", q.Title)
+	}
+	j.TurnedIn[q.ID] = true
+	return nil
+}
+
+func (q *Quest) Collect() map[int]int {
+	out := make(map[int]int)
+	for _, o := range q.Objectives {
+		if o.Kind == KindCollect {
+			out[o.TemplateID] += o.Count
+		}
+	}
+	return out
+}
+
+func (j *Journal) TurnedInList() []string {
+	return ids(j.TurnedIn)
+}
+
+func (j *Journal) AnnouncedList() []string {
+	return ids(j.Announced)
+}
+
+func ids(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for id, ok := range set {
+		if ok {
+			out = append(out, id)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (r Reward) String() string {
+	parts := make([]string, 0, 3)
+	if r.XP > 0 {
+		parts = append(parts, fmt.Sprintf("%d опыта", r.XP))
+	}
+	if r.Gold > 0 {
+		parts = append(parts, fmt.Sprintf("%d зол.", r.Gold))
+	}
+	for _, id := range r.Items {
+		if item, err := Item.CreateItem(id, r.Rarity, 1); err == nil {
+			parts = append(parts, item.GetFullName())
+		}
+	}
+	return strings.Join(parts, ", ")
+}
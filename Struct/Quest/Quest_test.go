@@ -0,0 +1,175 @@
+package Quest
+
+import (
+	"reflect"
+	"testing"
+
+	"MyGame/Struct/Item"
+)
+
+func mixedQuest() *Quest {
+	return &Quest{
+		ID:    "mixed",
+		Title: "Смешанное задание",
+		Objectives: []Objective{
+			{Kind: KindDefeat, Target: "goblin", Count: 2},
+			{Kind: KindCollect, TemplateID: 35, Count: 3},
+			{Kind: KindReach, Target: "hills", Count: 1},
+		},
+	}
+}
+
+func holding(counts map[int]int) Holdings {
+	return func(templateID int) int { return counts[templateID] }
+}
+
+func TestLoad(t *testing.T) {
+	quests, err := Load(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quests) == 0 {
+		t.Fatal("встроенные задания не загрузились")
+	}
+	if _, err := Load(func(Objective) bool { return false }); err == nil {
+		t.Error("неизвестные цели прошли проверку")
+	}
+
+	tests := []struct {
+		name  string
+		quest *Quest
+	}{
+		{"без id", &Quest{Objectives: mixedQuest().Objectives}},
+		{"без целей", &Quest{ID: "empty"}},
+		{"неизвестный тип", &Quest{ID: "x", Objectives: []Objective{{Kind: "escort", Count: 1}}}},
+		{"нулевое количество", &Quest{ID: "x", Objectives: []Objective{{Kind: KindDefeat, Target: "goblin"}}}},
+		{"отрицательная награда", &Quest{ID: "x", Objectives: mixedQuest().Objectives, Reward: Reward{Gold: -1}}},
+		{"неизвестный предмет", &Quest{ID: "x", Objectives: mixedQuest().Objectives, Reward: Reward{Items: []int{-1}}}},
+	}
+	for _, tt := range tests {
+		if err := tt.quest.validate(nil); err == nil {
+			t.Errorf("%s: ожидалась ошибка", tt.name)
+		}
+	}
+}
+
+func TestRecordAndCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   Kind
+		target string
+		amount int
+		want   []int
+	}{
+		{"победа над целью", KindDefeat, "goblin", 1, []int{1, 0, 0}},
+		{"счёт не превышает цель", KindDefeat, "goblin", 5, []int{2, 0, 0}},
+		{"чужая цель не считается", KindDefeat, "wolf", 1, nil},
+		{"достижение места", KindReach, "hills", 1, []int{0, 0, 1}},
+		{"сбор не записывается", KindCollect, "", 3, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, j := mixedQuest(), NewJournal()
+			if done := j.Record([]*Quest{q}, tt.kind, tt.target, tt.amount, nil); done != nil {
+				t.Errorf("задание выполнено раньше времени: %v", done)
+			}
+			if got := j.Progress[q.ID]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("прогресс %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectIsCountedFromHoldings(t *testing.T) {
+	tests := []struct {
+		have int
+		want int
+	}{
+		{0, 0},
+		{2, 2},
+		{3, 3},
+		{7, 3},
+	}
+	q, j := mixedQuest(), NewJournal()
+	for _, tt := range tests {
+		if got := j.Count(q, 1, holding(map[int]int{35: tt.have})); got != tt.want {
+			t.Errorf("в сумке %d: счёт %d, ожидалось %d", tt.have, got, tt.want)
+		}
+	}
+	if got := j.Count(q, 1, nil); got != 0 {
+		t.Errorf("без сумки счёт %d", got)
+	}
+	if got := q.Collect(); !reflect.DeepEqual(got, map[int]int{35: 3}) {
+		t.Errorf("Collect = %v", got)
+	}
+}
+
+func TestCompleteAndTurnIn(t *testing.T) {
+	q := mixedQuest()
+	quests := []*Quest{q}
+	j := NewJournal()
+	full, short := holding(map[int]int{35: 3}), holding(map[int]int{35: 2})
+
+	j.Record(quests, KindDefeat, "goblin", 2, full)
+	if done := j.Record(quests, KindReach, "hills", 1, short); done != nil {
+		t.Errorf("задание выполнено без слитков: %v", done)
+	}
+	if j.Complete(q, short) || j.CanTurnIn(q, short) == nil {
+		t.Error("задание сдаётся без слитков")
+	}
+	if done := j.Collected(quests, 35, full); len(done) != 1 {
+		t.Errorf("последний слиток не завершил задание: %v", done)
+	}
+	if done := j.Collected(quests, 35, holding(map[int]int{35: 4})); done != nil {
+		t.Errorf("лишний слиток снова завершил задание: %v", done)
+	}
+	j.Collected(quests, 35, short)
+	if done := j.Collected(quests, 35, full); done != nil {
+		t.Errorf("задание завершилось повторно после продажи слитка: %v", done)
+	}
+	if err := j.TurnIn(q); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.TurnIn(q); err == nil {
+		t.Error("награда выдана дважды")
+	}
+	if done := j.Record(quests, KindDefeat, "goblin", 1, full); done != nil || j.Collected(quests, 35, full) != nil {
+		t.Error("сданное задание снова завершилось")
+	}
+}
+
+func TestRestoreAndTurnedInList(t *testing.T) {
+	progress := map[string][]int{"mixed": {1, 0, 1}}
+	j := Restore(progress, []string{"b", "a"}, []string{"c"})
+	progress["mixed"][0] = 9
+	if got := j.Progress["mixed"]; !reflect.DeepEqual(got, []int{1, 0, 1}) {
+		t.Errorf("прогресс %v разделяет память с источником", got)
+	}
+	if got := j.TurnedInList(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("TurnedInList = %v", got)
+	}
+	if got := j.AnnouncedList(); !reflect.DeepEqual(got, []string{"c"}) {
+		t.Errorf("AnnouncedList = %v", got)
+	}
+}
+
+func TestRewardString(t *testing.T) {
+	item, err := Item.CreateItem(19, Item.Common, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		reward Reward
+		want   string
+	}{
+		{Reward{}, ""},
+		{Reward{XP: 50}, "50 опыта"},
+		{Reward{XP: 60, Gold: 30}, "60 опыта, 30 зол."},
+		{Reward{Gold: 60, Items: []int{19}}, "60 зол., " + item.GetFullName()},
+	}
+	for _, tt := range tests {
+		if got := tt.reward.String(); got != tt.want {
+			t.Errorf("%+v: %q, ожидалось %q", tt.reward, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package Shop
+
+import (
+	"fmt"
+	"math/rand"
+
+	"MyGame/Struct/Character"
+	"MyGame/Struct/Item"
+)
+
+const (
+	StockSize       = 6
+	BuybackSize     = 5
+	SellRatio       = 0.4
+	RotationBattles = 3
+)
+
+type Shop struct {
+	Stock    []*Item.Item
+	Buyback  []*Item.Item
+	Rotation int
+	stocked  bool
+}
+
+func New() *Shop {
+	return &Shop{
+		Stock:   make([]*Item.Item, 0, StockSize),
+		Buyback: make([]*Item.Item, 0, BuybackSize),
+	}
+}
+
+func (s *Shop) NeedsRestock(battles int) bool {
+	return !s.stocked || battles-s.Rotation >= RotationBattles
+}
+
+func (s *Shop) Restock(rng *rand.Rand, level, battles int) {
+	ids := Item.TemplateIDs()
+	s.Stock = s.Stock[:0]
+	for len(s.Stock) < StockSize && len(ids) > 0 {
+		item, err := Item.GenerateItem(rng, ids[rng.Intn(len(ids))], Item.RollRarity(rng, (level-1)/3), max(level, 1))
+		if err != nil {
+			continue
+		}
+		s.Stock = append(s.Stock, item)
+	}
+	s.Rotation = battles
+	s.stocked = true
+}
+
+func (s *Shop) Restore(stock, buyback []*Item.Item, rotation int) {
+	s.Stock, s.Buyback, s.Rotation = stock, buyback, rotation
+	s.stocked = len(stock) > 0
+}
+
+func (s *Shop) NextRotation() int {
+	return s.Rotation + RotationBattles
+}
+
+func SellPrice(item *Item.Item) int {
+	if item == nil {
+		return 0
+	}
+	return max(1, int(float64(item.Price)*SellRatio))
+}
+
+func (s *Shop) Buy(c *Character.Character, index int) (*Item.Item, error) {
+	if index < 0 || index >= len(s.Stock) {
+		return nil, fmt.Errorf("такого товара нет")
+	}
+	item := s.Stock[index]
+	if err := pay(c, item.Price); err != nil {
+		return nil, err
+	}
+	s.Stock = append(s.Stock[:index], s.Stock[index+1:]...)
+	return item, nil
+}
+
+func (s *Shop) BuyBack(c *Character.Character, index int) (*Item.Item, error) {
+	if index < 0 || index >= len(s.Buyback) {
+		return nil, fmt.Errorf("такого предмета нет в списке выкупа")
+	}
+	item := s.Buyback[index]
+	if err := pay(c, SellPrice(item)); err != nil {
+		return nil, err
+	}
+	s.Buyback = append(s.Buyback[:index], s.Buyback[index+1:]...)
+	return item, nil
+}
+
+func (s *Shop) Sell(c *Character.Character, index int) (*Item.Item, int, error) {
+	item, err := c.GetInventory().RemoveAt(index)
+	if err != nil {
+		return nil, 0, err
+	}
+	price := SellPrice(item)
+	c.Gold += price
+	s.Buyback = append([]*Item.Item{item}, s.Buyback...)
+	if len(s.Buyback) > BuybackSize {
+		s.Buyback = s.Buyback[:BuybackSize]
+	}
+	return item, price, nil
+}
+
+func Repairable(c *Character.Character) []*Item.Item {
+	out := make([]*Item.Item, 0)
+	for _, slot := range c.Equipment.GetAllEquipmentSlots() {
+		if item := c.Equipment.GetItem(slot); item != nil && item.RepairCost() > 0 {
+			out = append(out, item)
+		}
+	}
+	for _, item := range c.GetInventory().GetItems() {
+		if item != nil && item.Template != nil && item.Template.Slot != Item.SlotNone && item.RepairCost() > 0 {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func Repair(c *Character.Character, item *Item.Item) (int, error) {
+	price := item.RepairCost()
+	if price == 0 {
+		return 0, fmt.Errorf("предмет не нуждается в ремонте")
+	}
+	if c.Gold < price {
+		return 0, fmt.Errorf("не хватает золота: нужно %d, есть %d", price, c.Gold)
+	}
+	c.Gold -= price
+	item.Repair()
+	c.CalculateStats()
+	return price, nil
+}
+
+func pay(c *Character.Character, price int) error {
+	if c.GetInventory().IsFull() {
+		return fmt.Errorf("инвентарь полон")
+	}
+	if c.Gold < price {
+		return fmt.Errorf("не хватает золота: нужно %d, есть %d", price, c.Gold)
+	}
+	c.Gold -= price
+	return nil
+}
@@ -0,0 +1,151 @@
+package Shop
+
+import (
+	"math/rand"
+	"testing"
+
+	"MyGame/Struct/Character/chartest"
+	"MyGame/Struct/Item"
+)
+
+const wareID = 24
+
+func pricedItem(t *testing.T, price int) *Item.Item {
+	t.Helper()
+	item, err := Item.CreateItem(wareID, Item.Common, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	item.Price = price
+	return item
+}
+
+func TestSellPrice(t *testing.T) {
+	tests := []struct {
+		price int
+		want  int
+	}{
+		{100, 40},
+		{25, 10},
+		{1, 1},
+		{0, 1},
+	}
+	for _, tt := range tests {
+		if got := SellPrice(pricedItem(t, tt.price)); got != tt.want {
+			t.Errorf("SellPrice(%d) = %d, ожидалось %d", tt.price, got, tt.want)
+		}
+	}
+	if got := SellPrice(nil); got != 0 {
+		t.Errorf("SellPrice(nil) = %d", got)
+	}
+}
+
+func TestBuy(t *testing.T) {
+	tests := []struct {
+		name     string
+		gold     int
+		index    int
+		full     bool
+		wantErr  bool
+		wantGold int
+	}{
+		{"хватает золота", 100, 0, false, false, 40},
+		{"не хватает золота", 59, 0, false, true, 59},
+		{"нет такого товара", 100, 5, false, true, 100},
+		{"сумка полна", 100, 0, true, true, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := chartest.New(t)
+			c.Gold = tt.gold
+			if tt.full {
+				for !c.GetInventory().IsFull() {
+					if err := c.GetInventory().AddItem(pricedItem(t, 1)); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			s := New()
+			s.Stock = append(s.Stock, pricedItem(t, 60))
+			item, err := s.Buy(c, tt.index)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Buy err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if c.Gold != tt.wantGold {
+				t.Errorf("золото %d, ожидалось %d", c.Gold, tt.wantGold)
+			}
+			if !tt.wantErr && (item == nil || len(s.Stock) != 0) {
+				t.Errorf("товар не ушёл с прилавка: %d", len(s.Stock))
+			}
+		})
+	}
+}
+
+func TestSellAndBuyBack(t *testing.T) {
+	c := chartest.New(t)
+	s := New()
+	for i := 0; i < BuybackSize+2; i++ {
+		if err := c.GetInventory().AddItem(pricedItem(t, 50+i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := 0
+	for len(c.GetInventory().GetItems()) > 0 {
+		_, price, err := s.Sell(c, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want += price
+	}
+	if c.Gold != want || len(s.Buyback) != BuybackSize {
+		t.Fatalf("золото %d (ожидалось %d), выкуп %d", c.Gold, want, len(s.Buyback))
+	}
+	last := s.Buyback[0]
+	if last.Price != 50+BuybackSize+1 {
+		t.Errorf("первым в выкупе стоит не последний проданный предмет: цена %d", last.Price)
+	}
+
+	gold := c.Gold
+	item, err := s.BuyBack(c, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item != last || c.Gold != gold-SellPrice(last) || len(s.Buyback) != BuybackSize-1 {
+		t.Errorf("выкуп: золото %d, в списке %d", c.Gold, len(s.Buyback))
+	}
+	if _, err := s.BuyBack(c, BuybackSize); err == nil {
+		t.Error("выкуп несуществующего предмета прошёл")
+	}
+}
+
+func TestRestock(t *testing.T) {
+	s := New()
+	if !s.NeedsRestock(0) {
+		t.Fatal("новой лавке нужен товар")
+	}
+	s.Restock(rand.New(rand.NewSource(7)), 4, 10)
+	if len(s.Stock) != StockSize || s.Rotation != 10 || s.NextRotation() != 10+RotationBattles {
+		t.Fatalf("товаров %d, ротация %d", len(s.Stock), s.Rotation)
+	}
+	tests := []struct {
+		battles int
+		want    bool
+	}{
+		{10, false},
+		{10 + RotationBattles - 1, false},
+		{10 + RotationBattles, true},
+	}
+	for _, tt := range tests {
+		if got := s.NeedsRestock(tt.battles); got != tt.want {
+			t.Errorf("NeedsRestock(%d) = %v, ожидалось %v", tt.battles, got, tt.want)
+		}
+	}
+
+	again := New()
+	again.Restock(rand.New(rand.NewSource(7)), 4, 10)
+	for i := range s.Stock {
+		if s.Stock[i].GetFullName() != again.Stock[i].GetFullName() {
+			t.Errorf("один сид дал разный товар: %s и %s", s.Stock[i].GetFullName(), again.Stock[i].GetFullName())
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package Workshop
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"MyGame/Struct/Character"
+	"MyGame/Struct/Item"
+)
+
+//go:embed recipes.json
+var embeddedRecipes []byte
+
+const (
+	MaxItemLevel = 20
+	CombineCount = 3
+)
+
+type Ingredient struct {
+	TemplateID int `json:"template_id"`
+	Count      int `json:"count"`
+}
+
+type Result struct {
+	TemplateID int         `json:"template_id"`
+	Rarity     Item.Rarity `json:"rarity"`
+	Level      int         `json:"level"`
+}
+
+type Recipe struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Result      Result       `json:"result"`
+	Gold        int          `json:"gold"`
+	Ingredients []Ingredient `json:"ingredients"`
+}
+
+type Cost struct {
+	Gold      int
+	Materials []Ingredient
+}
+
+type recipesFile struct {
+	Recipes []*Recipe `json:"recipes"`
+}
+
+type Workshop struct {
+	recipes []*Recipe
+}
+
+func Load() (*Workshop, error) {
+	w := &Workshop{}
+	if err := w.LoadData(embeddedRecipes); err != nil {
+		return w, fmt.Errorf("встроенные рецепты: %w", err)
+	}
+	return w, nil
+}
+
+func (w *Workshop) LoadData(data []byte) error {
+	var file recipesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("ошибка разбора рецептов: %w", err)
+	}
+	for _, recipe := range file.Recipes {
+		if err := recipe.validate(); err != nil {
+			return err
+		}
+		w.recipes = append(w.recipes, recipe)
+	}
+	return nil
+}
+
+func (r *Recipe) validate() error {
+	if r == nil {
+		return fmt.Errorf("пустая запись рецепта")
+	}
+	if strings.TrimSpace(r.ID) == "" {
+		return fmt.Errorf("у рецепта '%s' не задан id", r.Name)
+	}
+	if r.Result.Level < 1 {
+		r.Result.Level = 1
+	}
+	item, err := Item.CreateItem(r.Result.TemplateID, r.Result.Rarity, r.Result.Level)
+	if err != nil {
+		return fmt.Errorf("рецепт '%s': %w", r.ID, err)
+	}
+	if r.Name == "" {
+		r.Name = item.Template.Name
+	}
+	if r.Gold < 0 {
+		return fmt.Errorf("рецепт '%s': отрицательная цена", r.ID)
+	}
+	if len(r.Ingredients) == 0 {
+		return fmt.Errorf("у рецепта '%s' нет ингредиентов", r.ID)
+	}
+	for _, ing := range r.Ingredients {
+		if ing.Count < 1 {
+			return fmt.Errorf("рецепт '%s': количество ингредиента %d должно быть положительным", r.ID, ing.TemplateID)
+		}
+		if _, err := Item.CreateItem(ing.TemplateID, Item.Common, 1); err != nil {
+			return fmt.Errorf("рецепт '%s': %w", r.ID, err)
+		}
+	}
+	return nil
+}
+
+func (w *Workshop) Recipes() []*Recipe {
+	if w == nil {
+		return nil
+	}
+	return w.recipes
+}
+
+func Upgradable(item *Item.Item) bool {
+	return item != nil && item.Template != nil && !item.IsEquipped && item.Template.Slot != Item.SlotNone
+}
+
+func UpgradeCost(item *Item.Item) Cost {
+	cost := Cost{
+		Gold:      10*item.Level + item.Price/2,
+		Materials: []Ingredient{{TemplateID: Item.IronIngotID, Count: 1 + item.Level/3}},
+	}
+	if item.Rarity >= Item.Rare {
+		cost.Materials = append(cost.Materials, Ingredient{TemplateID: Item.ArcaneDustID, Count: int(item.Rarity - Item.Uncommon)})
+	}
+	return cost
+}
+
+func CombineCost(item *Item.Item) Cost {
+	return Cost{Gold: 25 * int(item.Rarity+1)}
+}
+
+func Upgrade(c *Character.Character, index int) (*Item.Item, error) {
+	item, err := inventoryItem(c, index)
+	if err != nil {
+		return nil, err
+	}
+	if item.Level >= MaxItemLevel {
+		return nil, fmt.Errorf("достигнут максимальный уровень предмета (%d)", MaxItemLevel)
+	}
+	if err := spend(c, UpgradeCost(item), nil); err != nil {
+		return nil, err
+	}
+	if err := item.Rescale(item.Rarity, item.Level+1); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func Duplicates(c *Character.Character, index int) []*Item.Item {
+	items := c.GetInventory().GetItems()
+	if index < 0 || index >= len(items) {
+		return nil
+	}
+	target := items[index]
+	out := make([]*Item.Item, 0)
+	for i, item := range items {
+		if i != index && Upgradable(item) && item.Template == target.Template && item.Rarity == target.Rarity {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func Combine(c *Character.Character, index int) (*Item.Item, error) {
+	item, err := inventoryItem(c, index)
+	if err != nil {
+		return nil, err
+	}
+	if item.Rarity >= Item.Mythic {
+		return nil, fmt.Errorf("редкость уже максимальная")
+	}
+	duplicates := Duplicates(c, index)
+	if len(duplicates) < CombineCount-1 {
+		return nil, fmt.Errorf("нужно %d одинаковых предмета, есть %d", CombineCount, len(duplicates)+1)
+	}
+	used := duplicates[:CombineCount-1]
+	if err := spend(c, CombineCost(item), used); err != nil {
+		return nil, err
+	}
+	level := item.Level
+	for _, dup := range used {
+		level = max(level, dup.Level)
+	}
+	if err := item.Rescale(item.Rarity+1, level); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (r *Recipe) Cost() Cost {
+	return Cost{Gold: r.Gold, Materials: r.Ingredients}
+}
+
+func Craft(c *Character.Character, r *Recipe) (*Item.Item, error) {
+	item, err := Item.CreateItem(r.Result.TemplateID, r.Result.Rarity, r.Result.Level)
+	if err != nil {
+		return nil, err
+	}
+	if err := spend(c, r.Cost(), nil); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func Count(c *Character.Character, templateID int) int {
+	n := 0
+	for _, item := range c.GetInventory().GetItems() {
+		if item != nil && item.Template != nil && item.Template.ID == templateID && !item.IsEquipped {
+			n++
+		}
+	}
+	return n
+}
+
+func Consume(c *Character.Character, materials []Ingredient) error {
+	return spend(c, Cost{Materials: materials}, nil)
+}
+
+func Missing(c *Character.Character, cost Cost) []string {
+	out := make([]string, 0)
+	if c.Gold < cost.Gold {
+		out = append(out, fmt.Sprintf("золото %d/%d", c.Gold, cost.Gold))
+	}
+	for _, ing := range cost.Materials {
+		if have := Count(c, ing.TemplateID); have < ing.Count {
+			out = append(out, fmt.Sprintf("%s %d/%d", TemplateName(ing.TemplateID), have, ing.Count))
+		}
+	}
+	return out
+}
+
+func TemplateName(templateID int) string {
+	item, err := Item.CreateItem(templateID, Item.Common, 1)
+	if err != nil {
+		return "?"
+	}
+	return item.Template.Name
+}
+
+func inventoryItem(c *Character.Character, index int) (*Item.Item, error) {
+	items := c.GetInventory().GetItems()
+	if index < 0 || index >= len(items) {
+		return nil, fmt.Errorf("в инвентаре нет ячейки %d", index)
+	}
+	if !Upgradable(items[index]) {
+		return nil, fmt.Errorf("этот предмет нельзя улучшить")
+	}
+	return items[index], nil
+}
+
+func spend(c *Character.Character, cost Cost, extra []*Item.Item) error {
+	if missing := Missing(c, cost); len(missing) > 0 {
+		return fmt.Errorf("не хватает: %s", strings.Join(missing, ", "))
+	}
+	inv := c.GetInventory()
+	remove := make(map[*Item.Item]bool, len(extra))
+	for _, item := range extra {
+		remove[item] = true
+	}
+	for _, ing := range cost.Materials {
+		left := ing.Count
+		for _, item := range inv.GetItems() {
+			if left > 0 && !remove[item] && item.Template.ID == ing.TemplateID && !item.IsEquipped {
+				remove[item] = true
+				left--
+			}
+		}
+	}
+	indices := make([]int, 0, len(remove))
+	for i, item := range inv.GetItems() {
+		if remove[item] {
+			indices = append(indices, i)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(indices)))
+	for _, i := range indices {
+		if _, err := inv.RemoveAt(i); err != nil {
+			return err
+		}
+	}
+	c.Gold -= cost.Gold
+	return nil
+}
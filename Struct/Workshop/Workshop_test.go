@@ -0,0 +1,161 @@
+package Workshop
+
+import (
+	"reflect"
+	"testing"
+
+	"MyGame/Struct/Character"
+	"MyGame/Struct/Character/chartest"
+	"MyGame/Struct/Item"
+)
+
+const armorID = 31
+
+func newSmith(t *testing.T, gold int, materials map[int]int) *Character.Character {
+	t.Helper()
+	c := chartest.New(t)
+	c.Gold = gold
+	for id, n := range materials {
+		for i := 0; i < n; i++ {
+			chartest.Give(t, c, id, Item.Common, 1)
+		}
+	}
+	return c
+}
+
+func TestUpgradeCost(t *testing.T) {
+	tests := []struct {
+		name      string
+		rarity    Item.Rarity
+		level     int
+		materials []Ingredient
+	}{
+		{"обычный 1 ур.", Item.Common, 1, []Ingredient{{Item.IronIngotID, 1}}},
+		{"обычный 6 ур.", Item.Common, 6, []Ingredient{{Item.IronIngotID, 3}}},
+		{"редкий требует пыль", Item.Rare, 1, []Ingredient{{Item.IronIngotID, 1}, {Item.ArcaneDustID, 1}}},
+		{"мифический", Item.Mythic, 3, []Ingredient{{Item.IronIngotID, 2}, {Item.ArcaneDustID, int(Item.Mythic - Item.Uncommon)}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := Item.CreateItem(armorID, tt.rarity, tt.level)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cost := UpgradeCost(item)
+			if cost.Gold != 10*tt.level+item.Price/2 {
+				t.Errorf("золото %d", cost.Gold)
+			}
+			if !reflect.DeepEqual(cost.Materials, tt.materials) {
+				t.Errorf("материалы %v, ожидалось %v", cost.Materials, tt.materials)
+			}
+		})
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	tests := []struct {
+		name    string
+		gold    int
+		ingots  int
+		level   int
+		wantErr bool
+	}{
+		{"всё есть", 1000, 1, 1, false},
+		{"нет слитков", 1000, 0, 1, true},
+		{"нет золота", 0, 1, 1, true},
+		{"максимальный уровень", 1000, 10, MaxItemLevel, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newSmith(t, tt.gold, map[int]int{Item.IronIngotID: tt.ingots})
+			item := chartest.Give(t, c, armorID, Item.Common, tt.level)
+			index := len(c.GetInventory().GetItems()) - 1
+			cost := UpgradeCost(item)
+
+			_, err := Upgrade(c, index)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Upgrade err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if c.Gold != tt.gold || Count(c, Item.IronIngotID) != tt.ingots || item.Level != tt.level {
+					t.Errorf("неудачное улучшение что-то списало: золото %d, слитков %d", c.Gold, Count(c, Item.IronIngotID))
+				}
+				return
+			}
+			fresh, _ := Item.CreateItem(armorID, Item.Common, tt.level+1)
+			if item.Level != tt.level+1 || item.Defense != fresh.Defense {
+				t.Errorf("уровень %d, защита %.1f; ожидалось %d и %.1f", item.Level, item.Defense, tt.level+1, fresh.Defense)
+			}
+			if c.Gold != tt.gold-cost.Gold || Count(c, Item.IronIngotID) != tt.ingots-1 {
+				t.Errorf("списано неверно: золото %d, слитков %d", c.Gold, Count(c, Item.IronIngotID))
+			}
+		})
+	}
+}
+
+func TestCombine(t *testing.T) {
+	tests := []struct {
+		name       string
+		copies     int
+		rarity     Item.Rarity
+		wantErr    bool
+		wantRemain int
+	}{
+		{"три копии", 3, Item.Common, false, 1},
+		{"четыре копии", 4, Item.Uncommon, false, 2},
+		{"мало копий", 2, Item.Common, true, 2},
+		{"мифическая редкость", 3, Item.Mythic, true, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newSmith(t, 1000, nil)
+			for i := 0; i < tt.copies; i++ {
+				chartest.Give(t, c, armorID, tt.rarity, i+1)
+			}
+			item, err := Combine(c, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Combine err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(c.GetInventory().GetItems()); got != tt.wantRemain {
+				t.Errorf("в сумке %d предметов, ожидалось %d", got, tt.wantRemain)
+			}
+			if !tt.wantErr && (item.Rarity != tt.rarity+1 || item.Level != CombineCount || c.Gold != 1000-CombineCost(&Item.Item{Rarity: tt.rarity}).Gold) {
+				t.Errorf("редкость %v, уровень %d, золото %d", item.Rarity, item.Level, c.Gold)
+			}
+		})
+	}
+}
+
+func TestCraftAndConsume(t *testing.T) {
+	w, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipe := w.Recipes()[0]
+	materials := make(map[int]int)
+	for _, ing := range recipe.Ingredients {
+		materials[ing.TemplateID] += ing.Count
+	}
+
+	poor := newSmith(t, recipe.Gold-1, materials)
+	if _, err := Craft(poor, recipe); err == nil {
+		t.Error("рецепт сработал без золота")
+	}
+
+	c := newSmith(t, recipe.Gold, materials)
+	item, err := Craft(c, recipe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Template.ID != recipe.Result.TemplateID || c.Gold != 0 || len(c.GetInventory().GetItems()) != 0 {
+		t.Errorf("результат %d, золото %d, в сумке %d", item.Template.ID, c.Gold, len(c.GetInventory().GetItems()))
+	}
+
+	c = newSmith(t, 0, map[int]int{Item.IronIngotID: 3})
+	if err := Consume(c, []Ingredient{{Item.IronIngotID, 4}}); err == nil {
+		t.Error("списано больше, чем есть")
+	}
+	if err := Consume(c, []Ingredient{{Item.IronIngotID, 2}}); err != nil || Count(c, Item.IronIngotID) != 1 {
+		t.Errorf("Consume: %v, осталось %d", err, Count(c, Item.IronIngotID))
+	}
+}
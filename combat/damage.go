@@ -0,0 +1,225 @@
+package combat
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	icharacter "MyGame/Interface"
+	"MyGame/events"
+)
+
+type Stage string
+
+const (
+	StageBase      Stage = "база"
+	StageZone      Stage = "зона"
+	StageCrit      Stage = "крит"
+	StageDodge     Stage = "уклонение"
+	StageArmor     Stage = "броня"
+	StageResist    Stage = "сопротивление"
+	StageLifesteal Stage = "вампиризм"
+	StageInjury    Stage = "травма"
+	StageWear      Stage = "износ"
+)
+
+// Stages run in this order. StageResist has no built-in rule: it is the
+// hook for modifiers that scale the final amount, such as the round limit.
+// Damage is dealt after StageResist, so the stages after it see the final
+// res.Damage: lifesteal heals from it, then injuries and equipment wear
+// react to the landed hit.
+var stageOrder = []Stage{StageBase, StageZone, StageCrit, StageDodge, StageArmor, StageResist, StageLifesteal, StageInjury, StageWear}
+
+const (
+	baseSpread  = 0.2
+	minDamage   = 1
+	DefaultSeed = 1
+)
+
+type StageTrace struct {
+	Stage Stage
+	Value float64
+}
+
+type DamageResult struct {
+	Attacker icharacter.ICharacter
+	Defender icharacter.ICharacter
+	Zone     string
+	Amount   float64
+	Damage   int
+	Blocked  bool
+	Dodged   bool
+	Critical bool
+	Missed   bool
+	SelfHit  bool
+	Healed   int
+	Injury   *Injury
+	Broken   []string
+	Trace    []StageTrace
+}
+
+type ZoneArmored interface {
+	GetZoneDefense(zone string) float32
+}
+
+func DefenseAt(c icharacter.ICharacter, zone string) float32 {
+	if armored, ok := c.(ZoneArmored); ok {
+		return armored.GetZoneDefense(zone)
+	}
+	return c.GetDefense()
+}
+
+type Modifier func(res *DamageResult, rng *rand.Rand)
+
+type Pipeline struct {
+	rng       *rand.Rand
+	modifiers map[Stage][]Modifier
+}
+
+func NewPipeline(rng *rand.Rand) *Pipeline {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(DefaultSeed))
+	}
+	return &Pipeline{rng: rng, modifiers: make(map[Stage][]Modifier)}
+}
+
+func (p *Pipeline) Use(stage Stage, m Modifier) {
+	p.modifiers[stage] = append(p.modifiers[stage], m)
+}
+
+func (p *Pipeline) Clear(stage Stage) {
+	delete(p.modifiers, stage)
+}
+
+func ZoneMultiplier(zone string) float64 {
+	switch zone {
+	case "Голова":
+		return 1.5
+	case "Тело":
+		return 1.0
+	case "Правая нога", "Левая нога":
+		return 0.8
+	case "Правая рука", "Левая рука":
+		return 0.9
+	default:
+		return 1.0
+	}
+}
+
+func Blocked(attacker, defender icharacter.ICharacter, zone string) DamageResult {
+	return DamageResult{Attacker: attacker, Defender: defender, Zone: zone, Blocked: true}
+}
+
+func (p *Pipeline) Resolve(attacker, defender icharacter.ICharacter, zone string, factor float64) DamageResult {
+	res := DamageResult{Attacker: attacker, Defender: defender, Zone: zone, SelfHit: attacker == defender}
+	if attacker == nil || defender == nil || !defender.IsAlive() {
+		return res
+	}
+
+	for _, stage := range stageOrder {
+		p.apply(stage, &res, factor)
+		for _, m := range p.modifiers[stage] {
+			m(&res, p.rng)
+		}
+		res.Trace = append(res.Trace, StageTrace{Stage: stage, Value: res.Amount})
+		if res.Dodged && stage == StageDodge {
+			return res
+		}
+		if stage == StageResist {
+			res.Damage = max(minDamage, int(res.Amount))
+			before := defender.GetHP()
+			defender.TakeDamage(res.Damage)
+			res.Damage = before - defender.GetHP()
+		}
+	}
+	return res
+}
+
+func (p *Pipeline) apply(stage Stage, res *DamageResult, factor float64) {
+	attacker, defender := res.Attacker, res.Defender
+	switch stage {
+	case StageBase:
+		strength := float64(attacker.GetStrength()) * EffectsOf(attacker).StrengthMult
+		res.Amount = (strength + float64(int(attacker.GetAttack()))) * factor
+		res.Amount *= 1 - baseSpread/2 + p.rng.Float64()*baseSpread
+	case StageZone:
+		res.Amount *= ZoneMultiplier(res.Zone)
+	case StageCrit:
+		if p.rng.Float32() < attacker.GetCritChance() {
+			res.Critical = true
+			res.Amount *= float64(attacker.GetCritDamage())
+		}
+	case StageDodge:
+		if res.SelfHit {
+			return
+		}
+		if accuracy := EffectsOf(attacker).Accuracy; accuracy < 1 && p.rng.Float64() > accuracy {
+			res.Missed = true
+			res.Dodged = true
+			res.Amount = 0
+			return
+		}
+		if float64(p.rng.Float32()) < float64(defender.GetDodgeChance())*EffectsOf(defender).DodgeMult {
+			res.Dodged = true
+			res.Amount = 0
+		}
+	case StageArmor:
+		res.Amount = max(minDamage, res.Amount-float64(DefenseAt(defender, res.Zone)))
+	case StageLifesteal:
+		if res.SelfHit {
+			return
+		}
+		if heal := float32(res.Damage) * attacker.GetLifesteal(); heal >= 1 {
+			before := attacker.GetHP()
+			attacker.Heal(heal)
+			res.Healed = attacker.GetHP() - before
+		}
+	}
+}
+
+func (r DamageResult) String() string {
+	if r.Attacker == nil || r.Defender == nil {
+		return ""
+	}
+	head := fmt.Sprintf("%s → %s [%s]", r.Attacker.GetName(), r.Defender.GetName(), r.Zone)
+	if r.Blocked {
+		return head + ": блок"
+	}
+	steps := make([]string, 0, len(r.Trace))
+	for _, t := range r.Trace {
+		steps = append(steps, fmt.Sprintf("%s %.1f", t.Stage, t.Value))
+	}
+	return fmt.Sprintf("%s: %s = %d", head, strings.Join(steps, " → "), r.Damage)
+}
+
+func (r DamageResult) Event() events.Event {
+	if r.Attacker == nil || r.Defender == nil {
+		return events.Event{Kind: events.Failure, Message: "удар без участников"}
+	}
+	attacker, defender := r.Attacker.GetName(), r.Defender.GetName()
+	e := events.Event{Actor: attacker, Target: defender, Zone: r.Zone}
+	switch {
+	case r.Blocked:
+		e.Kind = events.Block
+		e.Message = fmt.Sprintf("%s блокирует атаку %s в %s и урон не наносится", defender, attacker, r.Zone)
+	case r.Missed:
+		e.Kind = events.Dodge
+		e.Message = fmt.Sprintf("%s промахивается по %s из-за травмы руки", attacker, defender)
+	case r.SelfHit:
+		e.Kind, e.Amount = events.Attack, float64(r.Damage)
+		e.Message = fmt.Sprintf("%s в замешательстве бьёт себя и получает %d урона. HP = %d/%d",
+			attacker, r.Damage, r.Attacker.GetHP(), r.Attacker.GetMaxHP())
+	case r.Dodged:
+		e.Kind = events.Dodge
+		e.Message = fmt.Sprintf("%s увернулся от атаки!", defender)
+	default:
+		e.Kind, e.Amount = events.Attack, float64(r.Damage)
+		prefix := ""
+		if r.Critical {
+			e.Kind, prefix = events.Critical, "КРИТИЧЕСКИЙ УДАР! "
+		}
+		e.Message = fmt.Sprintf("%s%s атакует %s в %s и наносит %d урона. %s HP = %d/%d",
+			prefix, attacker, defender, r.Zone, r.Damage, defender, r.Defender.GetHP(), r.Defender.GetMaxHP())
+	}
+	return e
+}
@@ -0,0 +1,206 @@
+package combat_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"MyGame/Struct/Character"
+	"MyGame/combat"
+	"MyGame/events"
+)
+
+func simulate(t *testing.T, seed int64) []events.Event {
+	t.Helper()
+	rng := rand.New(rand.NewSource(seed))
+	bus := events.NewBus()
+	var stream []events.Event
+	bus.SubscribeAll(func(e events.Event) { stream = append(stream, e) })
+
+	fighters := make([]*Character.Character, 2)
+	for i, name := range []string{"Рыцарь", "Разбойник"} {
+		c, err := Character.New(name, 80, 12, 8, 4)
+		if err != nil {
+			t.Fatalf("Character.New: %v", err)
+		}
+		c.CalculateStats()
+		c.SetRNG(rng)
+		c.Events = bus
+		fighters[i] = c
+	}
+
+	pipeline := combat.NewPipeline(rng)
+	pipeline.Use(combat.StageInjury, combat.InjuryModifier())
+	pipeline.Use(combat.StageWear, combat.WearModifier())
+	for turn := 0; turn < 200 && fighters[0].IsAlive() && fighters[1].IsAlive(); turn++ {
+		attacker, defender := fighters[turn%2], fighters[(turn+1)%2]
+		zone, block := attacker.Hit(), defender.Block()
+		if zone == block {
+			bus.Emit(combat.Blocked(attacker, defender, zone).Event())
+			continue
+		}
+		bus.Emit(pipeline.Resolve(attacker, defender, zone, 1.0).Event())
+	}
+	return stream
+}
+
+func TestBattleIsDeterministicForSeed(t *testing.T) {
+	first := simulate(t, 42)
+	if len(first) == 0 {
+		t.Fatal("бой не породил ни одного события")
+	}
+	if second := simulate(t, 42); !reflect.DeepEqual(first, second) {
+		t.Fatalf("один и тот же сид дал разные бои:\n%v\n%v", first, second)
+	}
+	if other := simulate(t, 43); reflect.DeepEqual(first, other) {
+		t.Fatal("разные сиды дали одинаковые бои")
+	}
+}
+
+func TestDamageEventMatchesLostHP(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		attacker, err := Character.New("Рыцарь", 80, 12, 8, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defender, err := Character.New("Разбойник", 80, 12, 8, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		attacker.CalculateStats()
+		defender.CalculateStats()
+
+		bus := events.NewBus()
+		var got []events.Event
+		bus.Subscribe(events.Attack, func(e events.Event) { got = append(got, e) })
+		bus.Subscribe(events.Critical, func(e events.Event) { got = append(got, e) })
+		bus.Subscribe(events.Dodge, func(e events.Event) { got = append(got, e) })
+
+		before := defender.GetHP()
+		res := combat.NewPipeline(rng).Resolve(attacker, defender, "Тело", 1.0)
+		bus.Emit(res.Event())
+		if len(got) != 1 {
+			t.Fatalf("сид %d: событий %d, ожидалось 1", seed, len(got))
+		}
+		e := got[0]
+		if res.Dodged {
+			if e.Kind != events.Dodge || defender.GetHP() != before {
+				t.Errorf("сид %d: уклонение дало %v и потерю HP %d", seed, e.Kind, before-defender.GetHP())
+			}
+			continue
+		}
+		if e.Amount != float64(before-defender.GetHP()) || e.Target != defender.GetName() {
+			t.Errorf("сид %d: событие %+v, потеряно HP %d", seed, e, before-defender.GetHP())
+		}
+		if res.Critical != (e.Kind == events.Critical) {
+			t.Errorf("сид %d: крит %v, но событие %v", seed, res.Critical, e.Kind)
+		}
+	}
+}
+
+func TestZoneMultiplier(t *testing.T) {
+	tests := []struct {
+		zone string
+		want float64
+	}{
+		{"Голова", 1.5},
+		{"Тело", 1.0},
+		{"Левая нога", 0.8},
+		{"Правая нога", 0.8},
+		{"Левая рука", 0.9},
+		{"Правая рука", 0.9},
+		{"Хвост", 1.0},
+	}
+	for _, tt := range tests {
+		if got := combat.ZoneMultiplier(tt.zone); got != tt.want {
+			t.Errorf("ZoneMultiplier(%q) = %v, ожидалось %v", tt.zone, got, tt.want)
+		}
+	}
+}
+
+func steadyFighter(t *testing.T, name string, defense, lifesteal float32) *Character.Character {
+	t.Helper()
+	c, err := Character.New(name, 500, 10, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.CalculateStats()
+	c.CritChance = 0
+	c.Evasion = -1
+	c.Lifesteal = lifesteal
+	c.SetDefense(defense)
+	return c
+}
+
+func TestPipelineStages(t *testing.T) {
+	tests := []struct {
+		name       string
+		zone       string
+		base       float64
+		defense    float32
+		lifesteal  float32
+		wantDamage int
+		wantHealed int
+	}{
+		{"удар в тело", "Тело", 100, 0, 0, 100, 0},
+		{"удар в голову", "Голова", 100, 0, 0, 150, 0},
+		{"броня вычитается", "Тело", 100, 30, 0, 70, 0},
+		{"броня не опускает урон ниже минимума", "Рука", 10, 50, 0, 1, 0},
+		{"вампиризм лечит от нанесённого урона", "Тело", 100, 0, 0.5, 100, 50},
+	}
+	want := []combat.Stage{combat.StageBase, combat.StageZone, combat.StageCrit, combat.StageDodge,
+		combat.StageArmor, combat.StageResist, combat.StageLifesteal, combat.StageInjury, combat.StageWear}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attacker := steadyFighter(t, "Рыцарь", 0, tt.lifesteal)
+			defender := steadyFighter(t, "Разбойник", tt.defense, 0)
+			attacker.SetHP(200)
+
+			pipeline := combat.NewPipeline(rand.New(rand.NewSource(1)))
+			pipeline.Use(combat.StageBase, func(res *combat.DamageResult, _ *rand.Rand) { res.Amount = tt.base })
+			var seen int
+			pipeline.Use(combat.StageLifesteal, func(res *combat.DamageResult, _ *rand.Rand) { seen = res.Damage })
+
+			before := defender.GetHP()
+			res := pipeline.Resolve(attacker, defender, tt.zone, 1.0)
+			if res.Damage != tt.wantDamage || before-defender.GetHP() != tt.wantDamage {
+				t.Errorf("урон %d, потеряно HP %d, ожидалось %d", res.Damage, before-defender.GetHP(), tt.wantDamage)
+			}
+			if seen != res.Damage {
+				t.Errorf("стадия вампиризма увидела урон %d вместо %d", seen, res.Damage)
+			}
+			if res.Healed != tt.wantHealed {
+				t.Errorf("вылечено %d, ожидалось %d", res.Healed, tt.wantHealed)
+			}
+			stages := make([]combat.Stage, 0, len(res.Trace))
+			for _, step := range res.Trace {
+				stages = append(stages, step.Stage)
+			}
+			if !reflect.DeepEqual(stages, want) {
+				t.Errorf("стадии %v, ожидалось %v", stages, want)
+			}
+		})
+	}
+}
+
+func TestPipelineStopsOnDodgeAndDeadDefender(t *testing.T) {
+	attacker := steadyFighter(t, "Рыцарь", 0, 0)
+	defender := steadyFighter(t, "Разбойник", 0, 0)
+	pipeline := combat.NewPipeline(rand.New(rand.NewSource(1)))
+	pipeline.Use(combat.StageDodge, func(res *combat.DamageResult, _ *rand.Rand) { res.Dodged, res.Amount = true, 0 })
+
+	before := defender.GetHP()
+	res := pipeline.Resolve(attacker, defender, "Тело", 1.0)
+	if !res.Dodged || res.Damage != 0 || defender.GetHP() != before {
+		t.Errorf("уклонение нанесло урон: %+v", res)
+	}
+	if last := res.Trace[len(res.Trace)-1].Stage; last != combat.StageDodge {
+		t.Errorf("после уклонения конвейер дошёл до стадии %s", last)
+	}
+
+	defender.TakeDamage(defender.GetHP())
+	if res := combat.NewPipeline(nil).Resolve(attacker, defender, "Тело", 1.0); len(res.Trace) != 0 || res.Damage != 0 {
+		t.Errorf("удар по мёртвому прошёл конвейер: %+v", res)
+	}
+}
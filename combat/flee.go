@@ -0,0 +1,17 @@
+package combat
+
+const (
+	baseFleeChance  = 0.4
+	fleeAgilityStep = 0.03
+	minFleeChance   = 0.1
+	maxFleeChance   = 0.9
+)
+
+func FleeChance(agility int, pursuers []int) float64 {
+	fastest := 0
+	for _, a := range pursuers {
+		fastest = max(fastest, a)
+	}
+	chance := baseFleeChance + float64(agility-fastest)*fleeAgilityStep
+	return min(maxFleeChance, max(minFleeChance, chance))
+}
@@ -0,0 +1,137 @@
+package combat
+
+const (
+	gaugeThreshold = 100.0
+	baseInitiative = 20.0
+	minAttackSpeed = 0.3
+	defaultPreview = 6
+)
+
+func Speed(agility int, attackSpeed float32) float64 {
+	if attackSpeed < minAttackSpeed {
+		attackSpeed = minAttackSpeed
+	}
+	return (baseInitiative + float64(max(agility, 0))) * float64(attackSpeed)
+}
+
+type entry struct {
+	id     int
+	speed  float64
+	gauge  float64
+	acted  bool
+	active bool
+}
+
+type Scheduler struct {
+	entries []*entry
+	round   int
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{round: 1}
+}
+
+func (s *Scheduler) Add(id int, speed float64) {
+	if e := s.find(id); e != nil {
+		e.speed = speed
+		e.active = true
+		return
+	}
+	s.entries = append(s.entries, &entry{id: id, speed: speed, active: true})
+}
+
+func (s *Scheduler) SetSpeed(id int, speed float64) {
+	if e := s.find(id); e != nil {
+		e.speed = speed
+	}
+}
+
+func (s *Scheduler) Remove(id int) {
+	if e := s.find(id); e != nil {
+		e.active = false
+	}
+}
+
+func (s *Scheduler) Len() int {
+	n := 0
+	for _, e := range s.entries {
+		if e.active {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *Scheduler) Round() int {
+	return s.round
+}
+
+func (s *Scheduler) Next() int {
+	return next(s.entries, &s.round)
+}
+
+func (s *Scheduler) Peek(n int) []int {
+	if n <= 0 {
+		n = defaultPreview
+	}
+	clone := make([]*entry, len(s.entries))
+	for i, e := range s.entries {
+		c := *e
+		clone[i] = &c
+	}
+	round := s.round
+	out := make([]int, 0, n)
+	for len(out) < n {
+		id := next(clone, &round)
+		if id < 0 {
+			break
+		}
+		out = append(out, id)
+	}
+	return out
+}
+
+func (s *Scheduler) find(id int) *entry {
+	for _, e := range s.entries {
+		if e.id == id {
+			return e
+		}
+	}
+	return nil
+}
+
+func next(entries []*entry, round *int) int {
+	var chosen *entry
+	wait := 0.0
+	allActed := true
+	for _, e := range entries {
+		if !e.active || e.speed <= 0 {
+			continue
+		}
+		if !e.acted {
+			allActed = false
+		}
+		w := (gaugeThreshold - e.gauge) / e.speed
+		if chosen == nil || w < wait || (w == wait && e.speed > chosen.speed) {
+			chosen, wait = e, w
+		}
+	}
+	if chosen == nil {
+		return -1
+	}
+	if allActed {
+		*round++
+		for _, e := range entries {
+			e.acted = false
+		}
+	}
+	wait = max(wait, 0)
+	for _, e := range entries {
+		if e.active {
+			e.gauge += e.speed * wait
+		}
+	}
+	chosen.gauge -= gaugeThreshold
+	chosen.acted = true
+	return chosen.id
+}
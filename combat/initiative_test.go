@@ -0,0 +1,96 @@
+package combat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSpeed(t *testing.T) {
+	tests := []struct {
+		name        string
+		agility     int
+		attackSpeed float32
+		want        float64
+	}{
+		{"базовая скорость", 0, 1, baseInitiative},
+		{"ловкость добавляется", 10, 1, baseInitiative + 10},
+		{"скорость атаки умножает", 10, 2, (baseInitiative + 10) * 2},
+		{"отрицательная ловкость не снижает", -5, 1, baseInitiative},
+		{"слишком медленная атака ограничена", 0, 0.1, baseInitiative * minAttackSpeed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Speed(tt.agility, tt.attackSpeed)
+			if diff := got - tt.want; diff > 1e-6 || diff < -1e-6 {
+				t.Errorf("Speed(%d, %.1f) = %.4f, ожидалось %.4f", tt.agility, tt.attackSpeed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedulerOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		speeds []float64
+		turns  int
+		want   []int
+	}{
+		{"равные скорости чередуются", []float64{30, 30}, 4, []int{0, 1, 0, 1}},
+		{"быстрый ходит первым и при равенстве", []float64{20, 40}, 3, []int{1, 1, 0}},
+		{"вдвое быстрее — вдвое больше ходов", []float64{60, 30}, 6, []int{0, 0, 1, 0, 0, 1}},
+		{"нулевая скорость не ходит", []float64{0, 25}, 3, []int{1, 1, 1}},
+		{"пустая очередь", nil, 1, []int{-1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScheduler()
+			for id, speed := range tt.speeds {
+				s.Add(id, speed)
+			}
+			got := make([]int, 0, tt.turns)
+			for i := 0; i < tt.turns; i++ {
+				got = append(got, s.Next())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("порядок ходов %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedulerPeekAndRemove(t *testing.T) {
+	s := NewScheduler()
+	s.Add(1, 30)
+	s.Add(2, 60)
+	peek := s.Peek(4)
+	if got := s.Peek(4); !reflect.DeepEqual(got, peek) {
+		t.Fatalf("Peek изменил очередь: %v, затем %v", peek, got)
+	}
+	for i, id := range peek {
+		if got := s.Next(); got != id {
+			t.Fatalf("ход %d: Next() = %d, Peek обещал %d", i, got, id)
+		}
+	}
+
+	s.Remove(2)
+	if s.Len() != 1 {
+		t.Fatalf("Len() = %d после удаления", s.Len())
+	}
+	for i := 0; i < 3; i++ {
+		if got := s.Next(); got != 1 {
+			t.Fatalf("после удаления ходит %d", got)
+		}
+	}
+}
+
+func TestSchedulerRounds(t *testing.T) {
+	s := NewScheduler()
+	s.Add(0, 30)
+	s.Add(1, 30)
+	for i := 0; i < 4; i++ {
+		s.Next()
+	}
+	if s.Round() != 2 {
+		t.Errorf("после двух полных кругов Round() = %d, ожидалось 2", s.Round())
+	}
+}
@@ -0,0 +1,129 @@
+package combat
+
+import (
+	"fmt"
+	"math/rand"
+
+	icharacter "MyGame/Interface"
+)
+
+type InjuryKind string
+
+const (
+	InjuryArm       InjuryKind = "arm"
+	InjuryLeg       InjuryKind = "leg"
+	InjuryStun      InjuryKind = "stun"
+	InjuryConfusion InjuryKind = "confusion"
+)
+
+const (
+	injuryChance      = 0.6
+	heavyHitShare     = 0.15
+	limbInjuryTurns   = 3
+	stunTurns         = 1
+	confusionTurns    = 2
+	ConfusionSelfHit  = 0.35
+	ConfusedHitFactor = 0.5
+)
+
+type Injury struct {
+	Kind  InjuryKind
+	Zone  string
+	Turns int
+}
+
+func (i Injury) Name() string {
+	switch i.Kind {
+	case InjuryArm:
+		return "Травма руки"
+	case InjuryLeg:
+		return "Травма ноги"
+	case InjuryStun:
+		return "Оглушение"
+	case InjuryConfusion:
+		return "Замешательство"
+	}
+	return "Травма"
+}
+
+func (i Injury) String() string {
+	return fmt.Sprintf("%s (%s, %d х.)", i.Name(), i.Zone, i.Turns)
+}
+
+type InjuryEffects struct {
+	StrengthMult float64
+	AgilityMult  float64
+	DodgeMult    float64
+	Accuracy     float64
+	Stunned      bool
+	Confused     bool
+}
+
+type Injurable interface {
+	GetInjuries() []Injury
+	AddInjury(injury Injury)
+}
+
+func EffectsOf(c icharacter.ICharacter) InjuryEffects {
+	eff := InjuryEffects{StrengthMult: 1, AgilityMult: 1, DodgeMult: 1, Accuracy: 1}
+	target, ok := c.(Injurable)
+	if !ok {
+		return eff
+	}
+	for _, injury := range target.GetInjuries() {
+		switch injury.Kind {
+		case InjuryArm:
+			eff.StrengthMult *= 0.75
+			eff.Accuracy -= 0.15
+		case InjuryLeg:
+			eff.AgilityMult *= 0.7
+			eff.DodgeMult *= 0.5
+		case InjuryStun:
+			eff.Stunned = true
+		case InjuryConfusion:
+			eff.Confused = true
+		}
+	}
+	return eff
+}
+
+func EffectiveAgility(c icharacter.ICharacter) int {
+	return max(1, int(float64(c.GetAgility())*EffectsOf(c).AgilityMult))
+}
+
+func InjuryFor(zone string, rng *rand.Rand) (Injury, bool) {
+	switch zone {
+	case "Правая рука", "Левая рука":
+		return Injury{Kind: InjuryArm, Zone: zone, Turns: limbInjuryTurns}, true
+	case "Правая нога", "Левая нога":
+		return Injury{Kind: InjuryLeg, Zone: zone, Turns: limbInjuryTurns}, true
+	case "Голова":
+		if rng.Float64() < 0.5 {
+			return Injury{Kind: InjuryStun, Zone: zone, Turns: stunTurns}, true
+		}
+		return Injury{Kind: InjuryConfusion, Zone: zone, Turns: confusionTurns}, true
+	}
+	return Injury{}, false
+}
+
+func InjuryModifier() Modifier {
+	return func(res *DamageResult, rng *rand.Rand) {
+		if res.Damage <= 0 || res.Defender == nil || !res.Defender.IsAlive() {
+			return
+		}
+		target, ok := res.Defender.(Injurable)
+		if !ok {
+			return
+		}
+		heavy := res.Critical || float64(res.Damage) >= heavyHitShare*float64(res.Defender.GetMaxHP())
+		if !heavy || rng.Float64() >= injuryChance {
+			return
+		}
+		injury, ok := InjuryFor(res.Zone, rng)
+		if !ok {
+			return
+		}
+		target.AddInjury(injury)
+		res.Injury = &injury
+	}
+}
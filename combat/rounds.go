@@ -0,0 +1,112 @@
+package combat
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	icharacter "MyGame/Interface"
+	"MyGame/config"
+)
+
+const suddenDeathGrowth = 2.0
+
+type Verdict int
+
+const (
+	VerdictNone Verdict = iota
+	VerdictSideA
+	VerdictSideB
+	VerdictDraw
+)
+
+type RoundLimit struct {
+	Max  int
+	Rule config.RoundRule
+}
+
+func NewRoundLimit(max int, rule config.RoundRule) RoundLimit {
+	if _, err := config.ParseRoundRule(string(rule)); err != nil {
+		rule = config.RoundRuleDraw
+	}
+	return RoundLimit{Max: max, Rule: rule}
+}
+
+func (l RoundLimit) Exceeded(round int) bool {
+	return l.Max > 0 && round > l.Max
+}
+
+func (l RoundLimit) SuddenDeath(round int) bool {
+	return l.Rule == config.RoundRuleSuddenDeath && l.Exceeded(round)
+}
+
+func (l RoundLimit) DamageFactor(round int) float64 {
+	if !l.SuddenDeath(round) {
+		return 1
+	}
+	return math.Pow(suddenDeathGrowth, float64(round-l.Max))
+}
+
+func (l *RoundLimit) Modifier(round func() int) Modifier {
+	return func(res *DamageResult, _ *rand.Rand) {
+		res.Amount *= l.DamageFactor(round())
+	}
+}
+
+func (l RoundLimit) Judge(round int, a, b []icharacter.ICharacter) Verdict {
+	return l.JudgeShares(round, HPShare(a), HPShare(b))
+}
+
+func (l RoundLimit) JudgeShares(round int, shareA, shareB float64) Verdict {
+	if !l.Exceeded(round) {
+		return VerdictNone
+	}
+	switch l.Rule {
+	case config.RoundRuleSuddenDeath:
+		return VerdictNone
+	case config.RoundRuleHP:
+		switch {
+		case shareA > shareB:
+			return VerdictSideA
+		case shareB > shareA:
+			return VerdictSideB
+		}
+	}
+	return VerdictDraw
+}
+
+func (l RoundLimit) Label(round int) string {
+	if l.Max <= 0 {
+		return fmt.Sprintf("Раунд %d", round)
+	}
+	if l.SuddenDeath(round) {
+		return fmt.Sprintf("Раунд %d/%d ⚡ внезапная смерть ×%.0f", round, l.Max, l.DamageFactor(round))
+	}
+	return fmt.Sprintf("Раунд %d/%d", min(round, l.Max), l.Max)
+}
+
+func (l RoundLimit) RuleName() string {
+	switch l.Rule {
+	case config.RoundRuleHP:
+		return "победа по доле HP"
+	case config.RoundRuleSuddenDeath:
+		return "внезапная смерть"
+	default:
+		return "ничья"
+	}
+}
+
+func HPShare(chars []icharacter.ICharacter) float64 {
+	hp, maxHP := 0, 0
+	for _, c := range chars {
+		if c == nil {
+			continue
+		}
+		hp += c.GetHP()
+		maxHP += c.GetMaxHP()
+	}
+	if maxHP == 0 {
+		return 0
+	}
+	return float64(hp) / float64(maxHP)
+}
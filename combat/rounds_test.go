@@ -0,0 +1,67 @@
+package combat
+
+import (
+	"testing"
+
+	"MyGame/config"
+)
+
+func TestRoundLimitJudge(t *testing.T) {
+	tests := []struct {
+		name   string
+		limit  RoundLimit
+		round  int
+		shareA float64
+		shareB float64
+		want   Verdict
+	}{
+		{"лимит не достигнут", NewRoundLimit(10, config.RoundRuleHP), 10, 0.9, 0.1, VerdictNone},
+		{"без лимита", NewRoundLimit(0, config.RoundRuleDraw), 500, 0.9, 0.1, VerdictNone},
+		{"ничья по правилу", NewRoundLimit(10, config.RoundRuleDraw), 11, 0.9, 0.1, VerdictDraw},
+		{"по HP побеждает A", NewRoundLimit(10, config.RoundRuleHP), 11, 0.6, 0.4, VerdictSideA},
+		{"по HP побеждает B", NewRoundLimit(10, config.RoundRuleHP), 11, 0.2, 0.4, VerdictSideB},
+		{"равные доли HP", NewRoundLimit(10, config.RoundRuleHP), 11, 0.5, 0.5, VerdictDraw},
+		{"внезапная смерть не судит", NewRoundLimit(10, config.RoundRuleSuddenDeath), 15, 0.9, 0.1, VerdictNone},
+		{"неизвестное правило — ничья", NewRoundLimit(10, "coin_flip"), 11, 0.9, 0.1, VerdictDraw},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.limit.JudgeShares(tt.round, tt.shareA, tt.shareB); got != tt.want {
+				t.Errorf("JudgeShares = %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundLimitSuddenDeath(t *testing.T) {
+	limit := NewRoundLimit(5, config.RoundRuleSuddenDeath)
+	tests := []struct {
+		round  int
+		factor float64
+		label  string
+	}{
+		{3, 1, "Раунд 3/5"},
+		{5, 1, "Раунд 5/5"},
+		{6, 2, "Раунд 6/5 ⚡ внезапная смерть ×2"},
+		{8, 8, "Раунд 8/5 ⚡ внезапная смерть ×8"},
+	}
+	for _, tt := range tests {
+		if got := limit.DamageFactor(tt.round); got != tt.factor {
+			t.Errorf("DamageFactor(%d) = %v, ожидалось %v", tt.round, got, tt.factor)
+		}
+		if got := limit.Label(tt.round); got != tt.label {
+			t.Errorf("Label(%d) = %q, ожидалось %q", tt.round, got, tt.label)
+		}
+	}
+
+	draw := NewRoundLimit(5, config.RoundRuleDraw)
+	if got := draw.DamageFactor(9); got != 1 {
+		t.Errorf("без внезапной смерти множитель %v", got)
+	}
+	if got := draw.Label(9); got != "Раунд 5/5" {
+		t.Errorf("метка после лимита %q", got)
+	}
+	if got := NewRoundLimit(0, config.RoundRuleDraw).Label(42); got != "Раунд 42" {
+		t.Errorf("метка без лимита %q", got)
+	}
+}
@@ -0,0 +1,22 @@
+package combat
+
+import "math/rand"
+
+type Wearable interface {
+	WearWeapon() []string
+	WearArmor(zone string) []string
+}
+
+func WearModifier() Modifier {
+	return func(res *DamageResult, _ *rand.Rand) {
+		if res.Damage <= 0 || res.SelfHit {
+			return
+		}
+		if attacker, ok := res.Attacker.(Wearable); ok {
+			res.Broken = append(res.Broken, attacker.WearWeapon()...)
+		}
+		if defender, ok := res.Defender.(Wearable); ok {
+			res.Broken = append(res.Broken, defender.WearArmor(res.Zone)...)
+		}
+	}
+}
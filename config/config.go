@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"time"
 )
 
@@ -9,13 +11,45 @@ const (
 	MinTerminalWidth  = 120
 	MinTerminalHeight = 70
 	MaxBattleRounds   = 10
+	PvPHP             = 100
 	DefaultPlayerName = "Герой"
 )
 
+type BattleMode string
+
+const (
+	ModePvE BattleMode = "pve"
+	ModePvP BattleMode = "pvp"
+)
+
+type RoundRule string
+
+const (
+	RoundRuleDraw        RoundRule = "draw"
+	RoundRuleHP          RoundRule = "hp"
+	RoundRuleSuddenDeath RoundRule = "sudden_death"
+)
+
+var defaultRoundRules = map[BattleMode]RoundRule{
+	ModePvE: RoundRuleSuddenDeath,
+	ModePvP: RoundRuleHP,
+}
+
+func ParseRoundRule(s string) (RoundRule, error) {
+	switch rule := RoundRule(s); rule {
+	case RoundRuleDraw, RoundRuleHP, RoundRuleSuddenDeath:
+		return rule, nil
+	default:
+		return "", fmt.Errorf("неизвестное правило лимита раундов '%s': ожидается draw, hp или sudden_death", s)
+	}
+}
+
 type GameConfig struct {
 	ScreenWidth     int
 	ScreenHeight    int
 	BattleRounds    int
+	RoundRules      map[BattleMode]RoundRule
+	XPCurve         XPCurve
 	TypewriterSpeed time.Duration
 
 	AutoSave       bool
@@ -24,23 +58,58 @@ type GameConfig struct {
 	PlayerName     string
 	LoggingEnabled bool
 	LogLevel       string
+
+	Seed       int64
+	BattleSeed int64
 }
 
 func Load() *GameConfig {
 	cfg := DefaultConfig()
+	cfg.Seed = seedFromEnv("GAME_SEED")
+	cfg.BattleSeed = seedFromEnv("BATTLE_SEED")
+	if v := os.Getenv("DIFFICULTY"); v != "" {
+		cfg.Difficulty = v
+	}
+	if rounds, err := strconv.Atoi(os.Getenv("BATTLE_ROUNDS")); err == nil {
+		cfg.BattleRounds = rounds
+	}
+	if base, err := strconv.Atoi(os.Getenv("XP_BASE")); err == nil {
+		cfg.XPCurve.Base = base
+	}
+	if growth, err := strconv.ParseFloat(os.Getenv("XP_GROWTH"), 64); err == nil {
+		cfg.XPCurve.Growth = growth
+	}
+	for mode, name := range map[BattleMode]string{ModePvE: "PVE_ROUND_RULE", ModePvP: "PVP_ROUND_RULE"} {
+		if v := os.Getenv(name); v != "" {
+			cfg.RoundRules[mode] = RoundRule(v)
+		}
+	}
 	_ = cfg.Validate()
 	return cfg
 }
 
+func seedFromEnv(name string) int64 {
+	seed, err := strconv.ParseInt(os.Getenv(name), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return seed
+}
+
 func DefaultConfig() *GameConfig {
 	return &GameConfig{
-		ScreenWidth:     MinTerminalWidth,
-		ScreenHeight:    MinTerminalHeight,
-		BattleRounds:    MaxBattleRounds,
+		ScreenWidth:  MinTerminalWidth,
+		ScreenHeight: MinTerminalHeight,
+		BattleRounds: MaxBattleRounds,
+		RoundRules: map[BattleMode]RoundRule{
+			ModePvE: defaultRoundRules[ModePvE],
+			ModePvP: defaultRoundRules[ModePvP],
+		},
+		XPCurve:         DefaultXPCurve(),
 		TypewriterSpeed: 30 * time.Millisecond,
 		AutoSave:        false,
 		Language:        "ru",
-		Difficulty:      "normal",
+		Difficulty:      string(DifficultyNormal),
 		PlayerName:      DefaultPlayerName,
 		LoggingEnabled:  false,
 		LogLevel:        "info",
@@ -57,8 +126,12 @@ func (c *GameConfig) Validate() error {
 	if c.Language == "" {
 		c.Language = "ru"
 	}
-	if c.Difficulty == "" {
-		c.Difficulty = "normal"
+	var validateErr error
+	if _, err := ParseDifficulty(c.Difficulty); err != nil {
+		if c.Difficulty != "" {
+			validateErr = err
+		}
+		c.Difficulty = string(DifficultyNormal)
 	}
 	if c.PlayerName == "" {
 		c.PlayerName = DefaultPlayerName
@@ -72,5 +145,32 @@ func (c *GameConfig) Validate() error {
 	if c.ScreenHeight <= 0 {
 		c.ScreenHeight = MinTerminalHeight
 	}
-	return nil
+	if c.RoundRules == nil {
+		c.RoundRules = make(map[BattleMode]RoundRule)
+	}
+	if err := c.XPCurve.Validate(); err != nil {
+		if validateErr == nil {
+			validateErr = err
+		}
+		c.XPCurve = DefaultXPCurve()
+	}
+	for mode, def := range defaultRoundRules {
+		if _, err := ParseRoundRule(string(c.RoundRules[mode])); err != nil {
+			if c.RoundRules[mode] != "" && validateErr == nil {
+				validateErr = err
+			}
+			c.RoundRules[mode] = def
+		}
+	}
+	return validateErr
+}
+
+func (c *GameConfig) RoundRule(mode BattleMode) RoundRule {
+	if c == nil {
+		return defaultRoundRules[mode]
+	}
+	if rule, ok := c.RoundRules[mode]; ok {
+		return rule
+	}
+	return defaultRoundRules[mode]
 }
@@ -0,0 +1,113 @@
+package config
+
+import "fmt"
+
+type Difficulty string
+
+const (
+	DifficultyEasy      Difficulty = "easy"
+	DifficultyNormal    Difficulty = "normal"
+	DifficultyHard      Difficulty = "hard"
+	DifficultyNightmare Difficulty = "nightmare"
+)
+
+type DifficultyProfile struct {
+	ID          Difficulty
+	Name        string
+	Description string
+
+	EnemyHP       float64
+	EnemyStrength float64
+	EnemyAgility  float64
+	EnemyAI       string
+	EnemyPotions  int
+
+	PlayerPotions   int
+	LootRarityBonus int
+	LootChance      float64
+}
+
+var difficultyOrder = []Difficulty{DifficultyEasy, DifficultyNormal, DifficultyHard, DifficultyNightmare}
+
+var difficultyProfiles = map[Difficulty]DifficultyProfile{
+	DifficultyEasy: {
+		ID:              DifficultyEasy,
+		Name:            "Лёгкая",
+		Description:     "Противники слабее и не целятся в голову, у вас три зелья",
+		EnemyHP:         0.75,
+		EnemyStrength:   0.8,
+		EnemyAgility:    0.9,
+		EnemyAI:         "balanced",
+		EnemyPotions:    0,
+		PlayerPotions:   3,
+		LootRarityBonus: 0,
+		LootChance:      1.0,
+	},
+	DifficultyNormal: {
+		ID:              DifficultyNormal,
+		Name:            "Обычная",
+		Description:     "Противники такие, как описаны в бестиарии",
+		EnemyHP:         1.0,
+		EnemyStrength:   1.0,
+		EnemyAgility:    1.0,
+		EnemyPotions:    1,
+		PlayerPotions:   1,
+		LootRarityBonus: 0,
+		LootChance:      0.8,
+	},
+	DifficultyHard: {
+		ID:              DifficultyHard,
+		Name:            "Сложная",
+		Description:     "Противники крепче и сильнее, зато добыча ценнее",
+		EnemyHP:         1.3,
+		EnemyStrength:   1.2,
+		EnemyAgility:    1.1,
+		EnemyPotions:    2,
+		PlayerPotions:   1,
+		LootRarityBonus: 1,
+		LootChance:      0.7,
+	},
+	DifficultyNightmare: {
+		ID:              DifficultyNightmare,
+		Name:            "Кошмар",
+		Description:     "Все противники агрессивны, зелий нет, добыча лучшая",
+		EnemyHP:         1.6,
+		EnemyStrength:   1.4,
+		EnemyAgility:    1.25,
+		EnemyAI:         "aggressive",
+		EnemyPotions:    2,
+		PlayerPotions:   0,
+		LootRarityBonus: 2,
+		LootChance:      0.6,
+	},
+}
+
+func ParseDifficulty(s string) (Difficulty, error) {
+	d := Difficulty(s)
+	if _, ok := difficultyProfiles[d]; !ok {
+		return "", fmt.Errorf("неизвестная сложность '%s': ожидается easy, normal, hard или nightmare", s)
+	}
+	return d, nil
+}
+
+func Difficulties() []DifficultyProfile {
+	out := make([]DifficultyProfile, 0, len(difficultyOrder))
+	for _, id := range difficultyOrder {
+		out = append(out, difficultyProfiles[id])
+	}
+	return out
+}
+
+func DifficultyFor(s string) DifficultyProfile {
+	if profile, ok := difficultyProfiles[Difficulty(s)]; ok {
+		return profile
+	}
+	return difficultyProfiles[DifficultyNormal]
+}
+
+func (c *GameConfig) DifficultyProfile() DifficultyProfile {
+	if c == nil {
+		return DifficultyFor("")
+	}
+	return DifficultyFor(c.Difficulty)
+}
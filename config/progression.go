@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	DefaultXPBase   = 100
+	DefaultXPGrowth = 1.5
+)
+
+type XPCurve struct {
+	Base   int
+	Growth float64
+}
+
+func DefaultXPCurve() XPCurve {
+	return XPCurve{Base: DefaultXPBase, Growth: DefaultXPGrowth}
+}
+
+func (c XPCurve) Validate() error {
+	if c.Base <= 0 {
+		return fmt.Errorf("опыт до второго уровня должен быть положительным, получено %d", c.Base)
+	}
+	if c.Growth < 1 {
+		return fmt.Errorf("рост кривой опыта должен быть не меньше 1, получено %.2f", c.Growth)
+	}
+	return nil
+}
+
+func (c XPCurve) Next(level int) int {
+	if err := c.Validate(); err != nil {
+		c = DefaultXPCurve()
+	}
+	return int(math.Round(float64(c.Base) * math.Pow(c.Growth, float64(max(level, 1)-1))))
+}
+
+func (c *GameConfig) XPForLevel(level int) int {
+	if c == nil {
+		return DefaultXPCurve().Next(level)
+	}
+	return c.XPCurve.Next(level)
+}
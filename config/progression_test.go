@@ -0,0 +1,50 @@
+package config
+
+import "testing"
+
+func TestXPCurveNext(t *testing.T) {
+	tests := []struct {
+		name  string
+		curve XPCurve
+		level int
+		want  int
+	}{
+		{"первый уровень", DefaultXPCurve(), 1, 100},
+		{"второй уровень", DefaultXPCurve(), 2, 150},
+		{"третий уровень", DefaultXPCurve(), 3, 225},
+		{"уровень ниже первого", DefaultXPCurve(), 0, 100},
+		{"линейная кривая", XPCurve{Base: 50, Growth: 1}, 7, 50},
+		{"невалидная кривая заменяется стандартной", XPCurve{Base: 0, Growth: 0.5}, 2, 150},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.curve.Next(tt.level); got != tt.want {
+				t.Errorf("Next(%d) = %d, ожидалось %d", tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXPCurveValidate(t *testing.T) {
+	tests := []struct {
+		curve   XPCurve
+		wantErr bool
+	}{
+		{DefaultXPCurve(), false},
+		{XPCurve{Base: 1, Growth: 1}, false},
+		{XPCurve{Base: 0, Growth: 1.5}, true},
+		{XPCurve{Base: 100, Growth: 0.9}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.curve.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%+v: Validate() = %v, wantErr %v", tt.curve, err, tt.wantErr)
+		}
+	}
+}
+
+func TestXPForLevelWithoutConfig(t *testing.T) {
+	var cfg *GameConfig
+	if got := cfg.XPForLevel(2); got != DefaultXPCurve().Next(2) {
+		t.Errorf("XPForLevel без конфига = %d", got)
+	}
+}
@@ -104,6 +104,7 @@ const (
 	StateGameOver
 	StateVictory
 	StatePaused
+	StateDungeon
 )
 
 type GameEventType int
@@ -118,12 +119,14 @@ const (
 	EventGameSave
 	EventGameLoad
 	EventStateChange
+	EventStoryProgress
+	EventQuestComplete
 )
 
 func (gs GameState) String() string {
 	names := []string{
 		"Меню", "Бой", "Инвентарь", "Лицензия", "Настройки",
-		"Сюжет", "Загрузка", "Конец игры", "Победа", "Пауза",
+		"Сюжет", "Загрузка", "Конец игры", "Победа", "Пауза", "Подземелье",
 	}
 	if int(gs) < len(names) {
 		return names[gs]
@@ -135,7 +138,8 @@ func (get GameEventType) String() string {
 	names := []string{
 		"Начало боя", "Конец боя", "Повышение уровня", "Получение предмета",
 		"Использование предмета", "Смерть персонажа", "Сохранение игры",
-		"Загрузка игры", "Смена состояния",
+		"Загрузка игры", "Смена состояния", "Продвижение по сюжету",
+		"Выполнение задания",
 	}
 	if int(get) < len(names) {
 		return names[get]
@@ -143,7 +147,7 @@ func (get GameEventType) String() string {
 	return "Неизвестно"
 }
 
-func IsValidGameState(state GameState) bool { return state >= StateMenu && state <= StatePaused }
+func IsValidGameState(state GameState) bool { return state >= StateMenu && state <= StateDungeon }
 func IsCombatState(state GameState) bool    { return state == StateBattle }
 
 func CanSaveInState(state GameState) bool {
@@ -156,6 +160,37 @@ func CanSaveInState(state GameState) bool {
 	return true
 }
 
+type BattleOutcome int
+
+const (
+	OutcomeNone BattleOutcome = iota
+	OutcomeVictory
+	OutcomeDefeat
+	OutcomeDraw
+	OutcomeFled
+	OutcomeSurrender
+)
+
+func (o BattleOutcome) String() string {
+	names := []string{"Нет", "Победа", "Поражение", "Ничья", "Побег", "Сдача"}
+	if int(o) < len(names) {
+		return names[o]
+	}
+	return "Неизвестно"
+}
+
+type BattleResult struct {
+	Outcome    BattleOutcome
+	Enemies    []string
+	EnemyIDs   []string
+	Rounds     int
+	Difficulty string
+	Seed       int64
+	XP         int
+	Gold       int
+	Loot       []string
+}
+
 type GameEvent struct {
 	Type      GameEventType
 	Data      interface{}
@@ -2,6 +2,8 @@ package core
 
 import (
 	"fmt"
+	"math/rand"
+	"time"
 
 	"MyGame/config"
 	"MyGame/utils"
@@ -11,6 +13,8 @@ type Dependencies struct {
 	Terminal *utils.TerminalManager
 	Logger   *utils.Logger
 	Config   *config.GameConfig
+	RNG      *rand.Rand
+	Seed     int64
 }
 
 func NewDependencies(cfg *config.GameConfig) *Dependencies {
@@ -21,15 +25,40 @@ func NewDependencies(cfg *config.GameConfig) *Dependencies {
 	if cfg.LoggingEnabled {
 		log.SetLevel(parseLogLevel(cfg.LogLevel))
 	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
 	d := &Dependencies{
 		Terminal: utils.NewTerminalManager(),
 		Logger:   log,
 		Config:   cfg,
+		RNG:      rand.New(rand.NewSource(seed)),
+		Seed:     seed,
 	}
 	_ = d.Validate()
+	log.Info("Сид игры: %d", seed)
 	return d
 }
 
+func (d *Dependencies) NewBattleRNG() (*rand.Rand, int64) {
+	var seed int64
+	switch {
+	case d == nil:
+		seed = time.Now().UnixNano()
+	case d.Config != nil && d.Config.BattleSeed != 0:
+		seed = d.Config.BattleSeed
+	case d.RNG != nil:
+		seed = d.RNG.Int63()
+	default:
+		seed = time.Now().UnixNano()
+	}
+	if d != nil && d.Logger != nil {
+		d.Logger.Info("Сид боя: %d", seed)
+	}
+	return rand.New(rand.NewSource(seed)), seed
+}
+
 func (d *Dependencies) Validate() error {
 	if d == nil {
 		return fmt.Errorf("deps is nil")
@@ -3,11 +3,22 @@ package core
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
+	"MyGame/Struct/Bestiary"
+	"MyGame/Struct/Campaign"
 	"MyGame/Struct/Character"
+	"MyGame/Struct/Dungeon"
+	"MyGame/Struct/Item"
+	"MyGame/Struct/Quest"
+	"MyGame/Struct/Shop"
+	"MyGame/Struct/Workshop"
 	"MyGame/config"
+	"MyGame/events"
 	"MyGame/utils"
 )
 
@@ -25,6 +36,12 @@ type GameManager struct {
 	running       bool
 	StartTime     time.Time
 	PlayTime      time.Duration
+	Difficulty    string
+	Shop          *Shop.Shop
+	Battles       int
+	Story         *Campaign.Progress
+	Journal       *Quest.Journal
+	Dungeon       *Dungeon.Dungeon
 	eventHandlers map[GameEventType][]func(*GameEvent)
 	stateHistory  []GameState
 	config        *InternalGameConfig
@@ -36,6 +53,9 @@ func NewGameManager() *GameManager {
 		running:       false,
 		StartTime:     time.Now(),
 		PlayTime:      0,
+		Shop:          Shop.New(),
+		Story:         Campaign.NewProgress(),
+		Journal:       Quest.NewJournal(),
 		eventHandlers: make(map[GameEventType][]func(*GameEvent)),
 		stateHistory:  make([]GameState, 0),
 		config: &InternalGameConfig{
@@ -116,6 +136,18 @@ func (gm *GameManager) SetPlayer(player *Character.Character) {
 	}
 }
 
+func (gm *GameManager) SetDifficulty(difficulty string) {
+	gm.mu.Lock()
+	gm.Difficulty = difficulty
+	gm.mu.Unlock()
+}
+
+func (gm *GameManager) GetDifficulty() string {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+	return gm.Difficulty
+}
+
 func (gm *GameManager) GetPlayer() *Character.Character {
 	gm.mu.RLock()
 	defer gm.mu.RUnlock()
@@ -191,16 +223,149 @@ func (gm *GameManager) CanSave() bool {
 }
 
 type GameSaveDTO struct {
-	PlayerName         string    `json:"player_name"`
-	PlayerCurrentHP    int       `json:"player_current_hp"`
-	PlayerMaxHP        int       `json:"player_max_hp"`
-	PlayerStrength     int       `json:"player_strength"`
-	PlayerAgility      int       `json:"player_agility"`
-	PlayerIntelligence int       `json:"player_intelligence"`
-	GameState          GameState `json:"game_state"`
-	PlayTimeNs         int64     `json:"play_time_ns"`
-	SaveTime           string    `json:"save_time"`
-	Version            string    `json:"version"`
+	PlayerName         string      `json:"player_name"`
+	PlayerCurrentHP    int         `json:"player_current_hp"`
+	PlayerMaxHP        int         `json:"player_max_hp"`
+	PlayerStrength     int         `json:"player_strength"`
+	PlayerAgility      int         `json:"player_agility"`
+	PlayerIntelligence int         `json:"player_intelligence"`
+	PlayerLevel        int         `json:"player_level"`
+	PlayerXP           int         `json:"player_xp"`
+	PlayerStatPoints   int         `json:"player_stat_points"`
+	PlayerAllocated    [4]int      `json:"player_allocated"`
+	PlayerGold         int         `json:"player_gold"`
+	PlayerClass        string      `json:"player_class,omitempty"`
+	PlayerInventory    []ItemDTO   `json:"player_inventory"`
+	PlayerEquipment    []ItemDTO   `json:"player_equipment"`
+	GameState          GameState   `json:"game_state"`
+	Difficulty         string      `json:"difficulty"`
+	Battles            int         `json:"battles"`
+	Shop               *ShopDTO    `json:"shop,omitempty"`
+	Story              *StoryDTO   `json:"story,omitempty"`
+	Quests             *QuestDTO   `json:"quests,omitempty"`
+	Dungeon            *DungeonDTO `json:"dungeon,omitempty"`
+	PlayTimeNs         int64       `json:"play_time_ns"`
+	SaveTime           string      `json:"save_time"`
+	Version            string      `json:"version"`
+}
+
+type ItemDTO struct {
+	TemplateID int         `json:"template_id"`
+	Rarity     Item.Rarity `json:"rarity"`
+	Level      int         `json:"level"`
+	Durability int         `json:"durability"`
+	Affixes    []string    `json:"affixes,omitempty"`
+}
+
+type StoryDTO struct {
+	Chapter int      `json:"chapter"`
+	Scene   int      `json:"scene"`
+	Flags   []string `json:"flags"`
+}
+
+type DungeonDTO struct {
+	Depth  int       `json:"depth"`
+	X      int       `json:"x"`
+	Y      int       `json:"y"`
+	HP     int       `json:"hp"`
+	Failed bool      `json:"failed"`
+	Rooms  []RoomDTO `json:"rooms"`
+}
+
+type RoomDTO struct {
+	X         int              `json:"x"`
+	Y         int              `json:"y"`
+	Kind      Dungeon.RoomKind `json:"kind"`
+	Encounter string           `json:"encounter,omitempty"`
+	Visited   bool             `json:"visited"`
+	Cleared   bool             `json:"cleared"`
+}
+
+func dungeonToDTO(d *Dungeon.Dungeon) *DungeonDTO {
+	if d == nil {
+		return nil
+	}
+	dto := &DungeonDTO{Depth: d.Depth, X: d.X, Y: d.Y, HP: d.HP, Failed: d.Failed}
+	for y, row := range d.Rooms {
+		for x, room := range row {
+			if room != nil {
+				dto.Rooms = append(dto.Rooms, RoomDTO{X: x, Y: y, Kind: room.Kind, Encounter: room.Encounter, Visited: room.Visited, Cleared: room.Cleared})
+			}
+		}
+	}
+	return dto
+}
+
+func dungeonFromDTO(dto *DungeonDTO) (*Dungeon.Dungeon, error) {
+	if dto == nil {
+		return nil, nil
+	}
+	rooms := make(map[[2]int]*Dungeon.Room, len(dto.Rooms))
+	for _, r := range dto.Rooms {
+		rooms[[2]int{r.X, r.Y}] = &Dungeon.Room{Kind: r.Kind, Encounter: r.Encounter, Visited: r.Visited, Cleared: r.Cleared}
+	}
+	return Dungeon.Restore(dto.Depth, dto.X, dto.Y, dto.HP, dto.Failed, rooms)
+}
+
+type QuestDTO struct {
+	Progress  map[string][]int `json:"progress"`
+	TurnedIn  []string         `json:"turned_in"`
+	Announced []string         `json:"announced,omitempty"`
+}
+
+type ShopDTO struct {
+	Stock    []ItemDTO `json:"stock"`
+	Buyback  []ItemDTO `json:"buyback"`
+	Rotation int       `json:"rotation"`
+}
+
+func itemsToDTO(items []*Item.Item) []ItemDTO {
+	out := make([]ItemDTO, 0, len(items))
+	for _, item := range items {
+		if item == nil || item.Template == nil {
+			continue
+		}
+		out = append(out, ItemDTO{TemplateID: item.Template.ID, Rarity: item.Rarity, Level: item.Level, Durability: item.Durability, Affixes: item.AffixIDs()})
+	}
+	return out
+}
+
+func itemsFromDTO(dtos []ItemDTO) ([]*Item.Item, error) {
+	out := make([]*Item.Item, 0, len(dtos))
+	for _, dto := range dtos {
+		item, err := Item.CreateItem(dto.TemplateID, dto.Rarity, dto.Level)
+		if err != nil {
+			return nil, err
+		}
+		if err := item.SetAffixIDs(dto.Affixes); err != nil {
+			return nil, err
+		}
+		item.Durability = dto.Durability
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+func restorePlayerItems(player *Character.Character, dto *GameSaveDTO) error {
+	if dto.PlayerInventory == nil && dto.PlayerEquipment == nil {
+		return nil
+	}
+	inventory, err := itemsFromDTO(dto.PlayerInventory)
+	if err != nil {
+		return fmt.Errorf("инвентарь: %w", err)
+	}
+	equipped, err := itemsFromDTO(dto.PlayerEquipment)
+	if err != nil {
+		return fmt.Errorf("экипировка: %w", err)
+	}
+	player.Inventory.Items = inventory
+	for _, item := range equipped {
+		if err := player.Equipment.Equip(item); err != nil {
+			return fmt.Errorf("экипировка: %w", err)
+		}
+	}
+	player.CalculateStats()
+	return nil
 }
 
 func (gm *GameManager) Save() ([]byte, error) {
@@ -213,24 +378,60 @@ func (gm *GameManager) Save() ([]byte, error) {
 	gm.mu.RLock()
 	player := gm.Player
 	state := gm.State
+	difficulty := gm.Difficulty
+	battles := gm.Battles
+	shop := gm.Shop
+	story := gm.Story
+	dungeon := dungeonToDTO(gm.Dungeon)
+	var quests *QuestDTO
+	if gm.Journal != nil {
+		quests = &QuestDTO{Progress: make(map[string][]int, len(gm.Journal.Progress)), TurnedIn: gm.Journal.TurnedInList(), Announced: gm.Journal.AnnouncedList()}
+		for id, counts := range gm.Journal.Progress {
+			quests.Progress[id] = append([]int(nil), counts...)
+		}
+	}
 	gm.mu.RUnlock()
 	if player == nil {
 		return nil, fmt.Errorf("игрок не установлен")
 	}
+	equipped := make([]*Item.Item, 0)
+	for _, slot := range player.Equipment.GetAllEquipmentSlots() {
+		if item := player.Equipment.GetItem(slot); item != nil {
+			equipped = append(equipped, item)
+		}
+	}
 	playTime := gm.GetPlayTime()
 	saveTime := time.Now()
 	dto := &GameSaveDTO{
 		PlayerName:         player.GetName(),
 		PlayerCurrentHP:    player.GetHP(),
-		PlayerMaxHP:        player.GetMaxHP(),
-		PlayerStrength:     player.GetStrength(),
-		PlayerAgility:      player.GetAgility(),
-		PlayerIntelligence: player.GetIntelligence(),
+		PlayerMaxHP:        player.GetBaseHP(),
+		PlayerStrength:     player.GetBaseStrength(),
+		PlayerAgility:      player.GetBaseAgility(),
+		PlayerIntelligence: player.GetBaseIntelligence(),
+		PlayerLevel:        player.GetLevel(),
+		PlayerXP:           player.XP,
+		PlayerStatPoints:   player.StatPoints,
+		PlayerAllocated:    player.Allocated,
+		PlayerGold:         player.Gold,
+		PlayerClass:        player.Class,
+		PlayerInventory:    itemsToDTO(player.Inventory.GetItems()),
+		PlayerEquipment:    itemsToDTO(equipped),
 		GameState:          state,
+		Difficulty:         difficulty,
+		Battles:            battles,
 		PlayTimeNs:         int64(playTime),
 		SaveTime:           saveTime.Format(time.RFC3339),
 		Version:            "1.0.0",
 	}
+	dto.Quests = quests
+	dto.Dungeon = dungeon
+	if story != nil {
+		dto.Story = &StoryDTO{Chapter: story.Chapter, Scene: story.Scene, Flags: story.FlagList()}
+	}
+	if shop != nil {
+		dto.Shop = &ShopDTO{Stock: itemsToDTO(shop.Stock), Buyback: itemsToDTO(shop.Buyback), Rotation: shop.Rotation}
+	}
 	data, err := json.Marshal(dto)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка сериализации данных сохранения: %w", err)
@@ -250,13 +451,52 @@ func (gm *GameManager) Load(data []byte) error {
 		return fmt.Errorf("создание персонажа при загрузке: %w", err)
 	}
 	player.CurrentHP = dto.PlayerCurrentHP
+	player.Level = max(dto.PlayerLevel, 1)
+	player.XP = max(dto.PlayerXP, 0)
+	player.StatPoints = max(dto.PlayerStatPoints, 0)
+	player.Allocated = dto.PlayerAllocated
+	player.Gold = max(dto.PlayerGold, 0)
+	player.Class = dto.PlayerClass
+	if err := restorePlayerItems(player, &dto); err != nil {
+		return fmt.Errorf("загрузка предметов: %w", err)
+	}
 	if player.CurrentHP > player.MaxHP {
 		player.CurrentHP = player.MaxHP
 	}
+	shop := Shop.New()
+	if dto.Shop != nil {
+		stock, err := itemsFromDTO(dto.Shop.Stock)
+		if err != nil {
+			return fmt.Errorf("товары торговца: %w", err)
+		}
+		buyback, err := itemsFromDTO(dto.Shop.Buyback)
+		if err != nil {
+			return fmt.Errorf("выкуп торговца: %w", err)
+		}
+		shop.Restore(stock, buyback, dto.Shop.Rotation)
+	}
+	dungeon, err := dungeonFromDTO(dto.Dungeon)
+	if err != nil {
+		return fmt.Errorf("подземелье: %w", err)
+	}
 	playTime := time.Duration(dto.PlayTimeNs)
 	gm.mu.Lock()
+	gm.Dungeon = dungeon
 	gm.Player = player
+	gm.Shop = shop
+	gm.Battles = max(dto.Battles, 0)
+	gm.Story = Campaign.NewProgress()
+	if dto.Story != nil {
+		gm.Story = Campaign.Restore(dto.Story.Chapter, dto.Story.Scene, dto.Story.Flags)
+	}
+	gm.Journal = Quest.NewJournal()
+	if dto.Quests != nil {
+		gm.Journal = Quest.Restore(dto.Quests.Progress, dto.Quests.TurnedIn, dto.Quests.Announced)
+	}
 	gm.State = dto.GameState
+	if dto.Difficulty != "" {
+		gm.Difficulty = dto.Difficulty
+	}
 	gm.StartTime = time.Now().Add(-playTime)
 	gm.PlayTime = playTime
 	gm.mu.Unlock()
@@ -322,8 +562,16 @@ func (gm *GameManager) GetSaveVersion() int {
 type ExtendedGameManager struct {
 	*GameManager
 
-	Config *config.GameConfig
-	Deps   *Dependencies
+	Config   *config.GameConfig
+	Deps     *Dependencies
+	Bestiary *Bestiary.Bestiary
+	Workshop *Workshop.Workshop
+	Campaign *Campaign.Campaign
+	Quests   []*Quest.Quest
+	Events   *events.Bus
+
+	levelUps []Character.LevelUp
+	loot     []*Item.Item
 }
 
 func NewExtendedGameManager() *ExtendedGameManager {
@@ -334,6 +582,7 @@ func NewExtendedGameManager() *ExtendedGameManager {
 		GameManager: NewGameManager(),
 		Config:      cfg,
 		Deps:        deps,
+		Events:      events.NewBus(),
 	}
 
 	player, err := Character.NewWarrior(config.DefaultPlayerName)
@@ -347,12 +596,373 @@ func NewExtendedGameManager() *ExtendedGameManager {
 		}
 	}
 
+	if player != nil {
+		player.Events = gm.Events
+	}
 	gm.GameManager.SetPlayer(player)
+	gm.GameManager.SetDifficulty(cfg.Difficulty)
+	gm.loadBestiary()
+	gm.loadItemSets()
+	gm.loadWorkshop()
+	gm.loadCampaign()
+	gm.loadQuests()
 	gm.registerEventHandlers()
 	return gm
 }
 
+func (gm *ExtendedGameManager) loadBestiary() {
+	bestiary, err := Bestiary.Load()
+	if err != nil && gm.Deps != nil && gm.Deps.Logger != nil {
+		gm.Deps.Logger.Error("Ошибка загрузки бестиария: %v", err)
+	}
+	if bestiary == nil {
+		bestiary = Bestiary.New()
+	}
+	gm.Bestiary = bestiary
+}
+
+func (gm *ExtendedGameManager) loadItemSets() {
+	if err := Item.SetsError(); err != nil && gm.Deps != nil && gm.Deps.Logger != nil {
+		gm.Deps.Logger.Error("Ошибка загрузки комплектов: %v", err)
+	}
+}
+
+func (gm *ExtendedGameManager) loadWorkshop() {
+	workshop, err := Workshop.Load()
+	if err != nil && gm.Deps != nil && gm.Deps.Logger != nil {
+		gm.Deps.Logger.Error("Ошибка загрузки рецептов: %v", err)
+	}
+	gm.Workshop = workshop
+}
+
+func (gm *ExtendedGameManager) loadCampaign() {
+	campaign, err := Campaign.Load(func(id string) bool { return gm.Bestiary.Encounter(id) != nil })
+	if err != nil && gm.Deps != nil && gm.Deps.Logger != nil {
+		gm.Deps.Logger.Error("Ошибка загрузки кампании: %v", err)
+	}
+	gm.Campaign = campaign
+}
+
+func (gm *ExtendedGameManager) loadQuests() {
+	quests, err := Quest.Load(func(o Quest.Objective) bool {
+		switch o.Kind {
+		case Quest.KindDefeat:
+			return gm.Bestiary.Get(o.Target) != nil
+		case Quest.KindCollect:
+			_, err := Item.CreateItem(o.TemplateID, Item.Common, 1)
+			return err == nil
+		}
+		return gm.Campaign.ChapterIndex(o.Target) >= 0
+	})
+	if err != nil && gm.Deps != nil && gm.Deps.Logger != nil {
+		gm.Deps.Logger.Error("Ошибка загрузки заданий: %v", err)
+	}
+	gm.Quests = quests
+}
+
+func (gm *ExtendedGameManager) QuestJournal() *Quest.Journal {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	if gm.GameManager.Journal == nil {
+		gm.GameManager.Journal = Quest.NewJournal()
+	}
+	return gm.GameManager.Journal
+}
+
+func (gm *ExtendedGameManager) QuestHoldings() Quest.Holdings {
+	player := gm.GetPlayer()
+	return func(templateID int) int {
+		if player == nil {
+			return 0
+		}
+		return Workshop.Count(player, templateID)
+	}
+}
+
+func (gm *ExtendedGameManager) recordQuest(kind Quest.Kind, target string) {
+	journal := gm.QuestJournal()
+	have := gm.QuestHoldings()
+	gm.mu.Lock()
+	completed := journal.Record(gm.Quests, kind, target, 1, have)
+	gm.mu.Unlock()
+	gm.announceQuests(completed)
+}
+
+func (gm *ExtendedGameManager) checkCollected(templateID int) {
+	journal := gm.QuestJournal()
+	have := gm.QuestHoldings()
+	gm.mu.Lock()
+	completed := journal.Collected(gm.Quests, templateID, have)
+	gm.mu.Unlock()
+	gm.announceQuests(completed)
+}
+
+func (gm *ExtendedGameManager) announceQuests(completed []*Quest.Quest) {
+	for _, q := range completed {
+		gm.emitEvent(EventQuestComplete, q, "Quest")
+		if gm.Deps != nil && gm.Deps.Logger != nil {
+			gm.Deps.Logger.Info("Задание выполнено: %s", q.Title)
+		}
+	}
+}
+
+func (gm *ExtendedGameManager) syncStoryQuests() {
+	gm.mu.RLock()
+	reached := 0
+	if gm.Story != nil {
+		reached = gm.Story.Chapter
+	}
+	gm.mu.RUnlock()
+	for i, chapter := range gm.Campaign.Chapters {
+		if i <= reached {
+			gm.recordQuest(Quest.KindReach, chapter.ID)
+		}
+	}
+}
+
+func (gm *ExtendedGameManager) TurnInQuest(q *Quest.Quest) ([]Character.LevelUp, error) {
+	player := gm.GetPlayer()
+	if player == nil {
+		return nil, fmt.Errorf("игрок не установлен")
+	}
+	journal := gm.QuestJournal()
+	have := gm.QuestHoldings()
+	gm.mu.RLock()
+	err := journal.CanTurnIn(q, have)
+	gm.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	materials := make([]Workshop.Ingredient, 0)
+	freed := 0
+	for id, count := range q.Collect() {
+		materials = append(materials, Workshop.Ingredient{TemplateID: id, Count: count})
+		freed += count
+	}
+	if free := player.GetInventory().GetEmptySlots() + freed; free < len(q.Reward.Items) {
+		return nil, fmt.Errorf("нужно свободных мест в сумке: %d", len(q.Reward.Items))
+	}
+	items := make([]*Item.Item, 0, len(q.Reward.Items))
+	for _, id := range q.Reward.Items {
+		item, err := Item.GenerateItem(gm.Deps.RNG, id, q.Reward.Rarity, player.GetLevel())
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := Workshop.Consume(player, materials); err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if err := gm.GiveItem(item); err != nil {
+			return nil, err
+		}
+	}
+	gm.mu.Lock()
+	err = journal.TurnIn(q)
+	gm.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	gm.AwardGold(q.Reward.Gold)
+	ups := gm.AwardXP(q.Reward.XP)
+	gm.AutoSave()
+	return ups, nil
+}
+
+func (gm *ExtendedGameManager) StoryScene() (*Campaign.Chapter, *Campaign.Scene) {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	return gm.Campaign.Current(gm.Story)
+}
+
+func (gm *ExtendedGameManager) StoryFinished() bool {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	return gm.Campaign.Finished(gm.Story)
+}
+
+func (gm *ExtendedGameManager) CompleteScene() (*Campaign.Scene, error) {
+	gm.mu.Lock()
+	scene := gm.Campaign.Complete(gm.Story)
+	gm.mu.Unlock()
+	if scene == nil {
+		return nil, fmt.Errorf("кампания уже пройдена")
+	}
+	gm.AwardGold(scene.Gold)
+	gm.emitEvent(EventStoryProgress, scene, "Campaign")
+	return scene, gm.SaveToFile()
+}
+
+func (gm *ExtendedGameManager) EnterDungeon() (*Dungeon.Dungeon, error) {
+	if gm.Dungeon == nil || gm.Dungeon.Failed {
+		if err := gm.generateDungeon(1); err != nil {
+			return nil, err
+		}
+	}
+	gm.SetState(StateDungeon)
+	return gm.Dungeon, nil
+}
+
+func (gm *ExtendedGameManager) generateDungeon(depth int) error {
+	player := gm.GetPlayer()
+	if player == nil {
+		return fmt.Errorf("игрок не установлен")
+	}
+	dungeon, err := Dungeon.Generate(gm.Deps.RNG, depth, gm.Bestiary)
+	if err != nil {
+		return err
+	}
+	dungeon.HP = player.GetMaxHP()
+	if gm.Dungeon != nil && !gm.Dungeon.Failed {
+		dungeon.HP = min(gm.Dungeon.HP, dungeon.HP)
+	}
+	gm.Dungeon = dungeon
+	if gm.Deps != nil && gm.Deps.Logger != nil {
+		gm.Deps.Logger.Info("%s спускается в подземелье, уровень %d", player.GetName(), depth)
+	}
+	return nil
+}
+
+func (gm *ExtendedGameManager) Descend() error {
+	if gm.Dungeon == nil {
+		return fmt.Errorf("вы не в подземелье")
+	}
+	if room := gm.Dungeon.Current(); room == nil || room.Kind != Dungeon.RoomExit {
+		return fmt.Errorf("здесь нет спуска")
+	}
+	if err := gm.generateDungeon(gm.Dungeon.Depth + 1); err != nil {
+		return err
+	}
+	gm.AutoSave()
+	return nil
+}
+
+func (gm *ExtendedGameManager) ExploreRoom(room *Dungeon.Room) string {
+	defer gm.AutoSave()
+	player := gm.GetPlayer()
+	if player == nil || room == nil || room.Cleared {
+		return ""
+	}
+	d := gm.Dungeon
+	switch room.Kind {
+	case Dungeon.RoomTreasure:
+		ids := Item.TemplateIDs()
+		if len(ids) == 0 {
+			return ""
+		}
+		rng := gm.Deps.RNG
+		item, err := Item.GenerateItem(rng, ids[rng.Intn(len(ids))], Item.RollRarity(rng, d.Depth-1), player.GetLevel())
+		if err != nil {
+			return fmt.Sprintf("❌ %v", err)
+		}
+		if err := gm.GiveItem(item); err != nil {
+			return fmt.Sprintf("🎒 %s остаётся лежать: %v", item.GetFullName(), err)
+		}
+		gold := d.Depth * (10 + rng.Intn(10))
+		gm.AwardGold(gold)
+		room.Cleared = true
+		return fmt.Sprintf("💎 Найдено: %s и %d зол.", item.GetFullName(), gold)
+	case Dungeon.RoomTrap:
+		damage := d.TrapDamage(player.GetMaxHP())
+		d.HP -= damage
+		room.Cleared = true
+		return fmt.Sprintf("⚠️ Ловушка! −%d HP", damage)
+	case Dungeon.RoomRest:
+		heal := d.RestHeal(player.GetMaxHP())
+		d.HP += heal
+		room.Cleared = true
+		return fmt.Sprintf("🔥 Привал у костра: +%d HP", heal)
+	case Dungeon.RoomEmpty:
+		room.Cleared = true
+	}
+	return ""
+}
+
+func (gm *ExtendedGameManager) FinishDungeonBattle(outcome BattleOutcome, hp int) {
+	d := gm.Dungeon
+	if d == nil {
+		return
+	}
+	d.HP = max(hp, 1)
+	switch outcome {
+	case OutcomeVictory:
+		if room := d.Current(); room != nil {
+			room.Cleared = true
+		}
+	case OutcomeDefeat:
+		d.Failed = true
+	default:
+		d.Retreat()
+	}
+}
+
+func SavePath() string {
+	if path := os.Getenv("SAVE_FILE"); path != "" {
+		return path
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "save.json"
+	}
+	return filepath.Join(configDir, "ItsHard", "save.json")
+}
+
+func HasSave() bool {
+	_, err := os.Stat(SavePath())
+	return err == nil
+}
+
+func (gm *ExtendedGameManager) SaveToFile() error {
+	data, err := gm.Save()
+	if err != nil {
+		return err
+	}
+	path := SavePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("создание каталога сохранений: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("запись сохранения %s: %w", path, err)
+	}
+	return nil
+}
+
+func (gm *ExtendedGameManager) AutoSave() {
+	if gm.config == nil || !gm.config.AutoSave {
+		return
+	}
+	if err := gm.SaveToFile(); err != nil && gm.Deps != nil && gm.Deps.Logger != nil {
+		gm.Deps.Logger.Error("Ошибка автосохранения: %v", err)
+	}
+}
+
+func (gm *ExtendedGameManager) LoadFromFile() error {
+	data, err := os.ReadFile(SavePath())
+	if err != nil {
+		return fmt.Errorf("чтение сохранения: %w", err)
+	}
+	return gm.Load(data)
+}
+
+func (gm *ExtendedGameManager) ResetProgress() {
+	gm.mu.Lock()
+	gm.Shop = Shop.New()
+	gm.Battles = 0
+	gm.Story = Campaign.NewProgress()
+	gm.PlayTime = 0
+	gm.StartTime = time.Now()
+	gm.levelUps = nil
+	gm.loot = nil
+	gm.GameManager.Journal = Quest.NewJournal()
+	gm.Dungeon = nil
+	gm.mu.Unlock()
+}
+
 func (gm *ExtendedGameManager) UpdatePlayer(player *Character.Character) {
+	if player != nil {
+		player.Events = gm.Events
+	}
 	gm.GameManager.SetPlayer(player)
 	if gm.Deps != nil && gm.Deps.Logger != nil && player != nil {
 		gm.Deps.Logger.Info("Игрок обновлен: %s", player.GetName())
@@ -368,6 +978,9 @@ func (gm *ExtendedGameManager) GetPlayerStats() map[string]interface{} {
 		"name":      player.GetName(),
 		"hp":        player.GetHP(),
 		"max_hp":    player.GetMaxHP(),
+		"level":     player.GetLevel(),
+		"xp":        player.XP,
+		"gold":      player.Gold,
 		"strength":  player.GetStrength(),
 		"attack":    player.GetAttack(),
 		"defense":   player.GetDefense(),
@@ -387,14 +1000,180 @@ func (gm *ExtendedGameManager) registerEventHandlers() {
 		}
 	})
 	gm.RegisterEventHandler(EventGameLoad, func(*GameEvent) {
+		if player := gm.GetPlayer(); player != nil {
+			player.Events = gm.Events
+		}
+		if gm.Config != nil {
+			if _, err := config.ParseDifficulty(gm.GetDifficulty()); err == nil {
+				gm.Config.Difficulty = gm.GetDifficulty()
+			}
+		}
+		gm.syncStoryQuests()
 		if gm.Deps != nil && gm.Deps.Logger != nil {
 			gm.Deps.Logger.Info("Игра загружена")
 		}
 	})
+	gm.RegisterEventHandler(EventBattleEnd, func(event *GameEvent) {
+		result, ok := event.Data.(BattleResult)
+		if !ok || result.Outcome != OutcomeVictory {
+			return
+		}
+		for _, id := range result.EnemyIDs {
+			gm.recordQuest(Quest.KindDefeat, id)
+		}
+	})
+	gm.RegisterEventHandler(EventItemAcquired, func(event *GameEvent) {
+		if item, ok := event.Data.(*Item.Item); ok && item.Template != nil {
+			gm.checkCollected(item.Template.ID)
+		}
+	})
+	gm.RegisterEventHandler(EventStoryProgress, func(*GameEvent) {
+		gm.syncStoryQuests()
+	})
+	gm.Events.SubscribeAll(func(e events.Event) {
+		if gm.Deps != nil && gm.Deps.Logger != nil {
+			gm.Deps.Logger.GameEvent(e.Kind.String(), e.Message)
+		}
+	})
+	gm.Events.Subscribe(events.Death, func(e events.Event) {
+		gm.emitEvent(EventCharacterDeath, e, "Character")
+	})
+	gm.Events.Subscribe(events.ItemUsed, func(e events.Event) {
+		gm.emitEvent(EventItemUsed, e, "Character")
+	})
 }
 
 func (gm *ExtendedGameManager) GetConfig() *config.GameConfig { return gm.Config }
 
+func (gm *ExtendedGameManager) StartBattle(enemies []string) {
+	gm.emitEvent(EventBattleStart, enemies, "FightModel")
+	if gm.Deps != nil && gm.Deps.Logger != nil {
+		gm.Deps.Logger.Info("Начало боя: %s", strings.Join(enemies, ", "))
+	}
+}
+
+func (gm *ExtendedGameManager) EndBattle(result BattleResult) {
+	gm.mu.Lock()
+	gm.Battles++
+	gm.mu.Unlock()
+	if player := gm.GetPlayer(); player != nil {
+		player.CalculateStats()
+	}
+	gm.emitEvent(EventBattleEnd, result, "FightModel")
+	if gm.Deps != nil && gm.Deps.Logger != nil {
+		gm.Deps.Logger.Info("Конец боя: %s (раундов: %d, противники: %s)",
+			result.Outcome, result.Rounds, strings.Join(result.Enemies, ", "))
+	}
+}
+
+func (gm *ExtendedGameManager) AwardXP(amount int) []Character.LevelUp {
+	player := gm.GetPlayer()
+	if player == nil || amount <= 0 {
+		return nil
+	}
+	ups := player.GainXP(amount, gm.Config.XPForLevel)
+	for _, up := range ups {
+		gm.emitEvent(EventPlayerLevelUp, up, "GameManager")
+		if gm.Deps != nil && gm.Deps.Logger != nil {
+			gm.Deps.Logger.Info("%s достиг уровня %d", player.GetName(), up.Level)
+		}
+	}
+	gm.mu.Lock()
+	gm.levelUps = append(gm.levelUps, ups...)
+	gm.mu.Unlock()
+	return ups
+}
+
+func (gm *ExtendedGameManager) HasLevelUps() bool {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+	return len(gm.levelUps) > 0
+}
+
+func (gm *ExtendedGameManager) TakeLevelUps() []Character.LevelUp {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	ups := gm.levelUps
+	gm.levelUps = nil
+	return ups
+}
+
+func (gm *ExtendedGameManager) AwardGold(amount int) {
+	player := gm.GetPlayer()
+	if player == nil || amount <= 0 {
+		return
+	}
+	player.Gold += amount
+	if gm.Deps != nil && gm.Deps.Logger != nil {
+		gm.Deps.Logger.Info("%s получает %d золота (всего %d)", player.GetName(), amount, player.Gold)
+	}
+}
+
+func (gm *ExtendedGameManager) AddLoot(items []*Item.Item) {
+	gm.mu.Lock()
+	gm.loot = append(gm.loot, items...)
+	gm.mu.Unlock()
+}
+
+func (gm *ExtendedGameManager) GiveItem(item *Item.Item) error {
+	player := gm.GetPlayer()
+	if player == nil {
+		return fmt.Errorf("игрок не установлен")
+	}
+	if err := player.GetInventory().AddItem(item); err != nil {
+		return err
+	}
+	gm.emitEvent(EventItemAcquired, item, "Inventory")
+	if gm.Deps != nil && gm.Deps.Logger != nil {
+		gm.Deps.Logger.Info("%s получает предмет: %s", player.GetName(), item.GetFullName())
+	}
+	return nil
+}
+
+func (gm *ExtendedGameManager) HasLoot() bool {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+	return len(gm.loot) > 0
+}
+
+func (gm *ExtendedGameManager) TakeLoot() []*Item.Item {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	loot := gm.loot
+	gm.loot = nil
+	return loot
+}
+
+func (gm *ExtendedGameManager) OpenShop() *Shop.Shop {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	if gm.Shop == nil {
+		gm.Shop = Shop.New()
+	}
+	if gm.Shop.NeedsRestock(gm.Battles) {
+		level := 1
+		if gm.Player != nil {
+			level = gm.Player.GetLevel()
+		}
+		gm.Shop.Restock(gm.Deps.RNG, level, gm.Battles)
+	}
+	return gm.Shop
+}
+
+func (gm *ExtendedGameManager) SetDifficulty(difficulty string) error {
+	if _, err := config.ParseDifficulty(difficulty); err != nil {
+		return err
+	}
+	if gm.Config != nil {
+		gm.Config.Difficulty = difficulty
+	}
+	gm.GameManager.SetDifficulty(difficulty)
+	if gm.Deps != nil && gm.Deps.Logger != nil {
+		gm.Deps.Logger.Info("Сложность изменена: %s", config.DifficultyFor(difficulty).Name)
+	}
+	return nil
+}
+
 func (gm *ExtendedGameManager) ValidateConfig() error {
 	if gm.Config == nil {
 		return fmt.Errorf("конфигурация не установлена")
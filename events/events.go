@@ -0,0 +1,121 @@
+package events
+
+import "sync"
+
+type Kind int
+
+const (
+	Attack Kind = iota
+	Block
+	Dodge
+	Critical
+	Death
+	Heal
+	ManaRestore
+	Buff
+	ItemEquipped
+	ItemUnequipped
+	ItemUsed
+	Failure
+	Info
+)
+
+var kindNames = map[Kind]string{
+	Attack:         "Атака",
+	Block:          "Блок",
+	Dodge:          "Уклонение",
+	Critical:       "Крит",
+	Death:          "Смерть",
+	Heal:           "Лечение",
+	ManaRestore:    "Мана",
+	Buff:           "Бафф",
+	ItemEquipped:   "Экипировка",
+	ItemUnequipped: "Снятие",
+	ItemUsed:       "Предмет",
+	Failure:        "Ошибка",
+	Info:           "Сведения",
+}
+
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return "Неизвестно"
+}
+
+type Event struct {
+	Kind    Kind
+	Actor   string
+	Target  string
+	Item    string
+	Zone    string
+	Amount  float64
+	Message string
+}
+
+type Handler func(Event)
+
+type subscription struct {
+	id      int
+	kind    Kind
+	all     bool
+	handler Handler
+}
+
+type Bus struct {
+	mu     sync.RWMutex
+	nextID int
+	subs   []subscription
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+func (b *Bus) Subscribe(kind Kind, h Handler) func() {
+	return b.add(subscription{kind: kind, handler: h})
+}
+
+func (b *Bus) SubscribeAll(h Handler) func() {
+	return b.add(subscription{all: true, handler: h})
+}
+
+func (b *Bus) add(s subscription) func() {
+	if b == nil || s.handler == nil {
+		return func() {}
+	}
+	b.mu.Lock()
+	b.nextID++
+	s.id = b.nextID
+	b.subs = append(b.subs, s)
+	b.mu.Unlock()
+	return func() { b.remove(s.id) }
+}
+
+func (b *Bus) remove(id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, s := range b.subs {
+		if s.id == id {
+			b.subs = append(b.subs[:i], b.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+func (b *Bus) Emit(e Event) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.subs))
+	for _, s := range b.subs {
+		if s.all || s.kind == e.Kind {
+			handlers = append(handlers, s.handler)
+		}
+	}
+	b.mu.RUnlock()
+	for _, h := range handlers {
+		h(e)
+	}
+}
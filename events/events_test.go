@@ -0,0 +1,55 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBusDelivery(t *testing.T) {
+	bus := NewBus()
+	var attacks, all []Kind
+	stopAttacks := bus.Subscribe(Attack, func(e Event) { attacks = append(attacks, e.Kind) })
+	stopAll := bus.SubscribeAll(func(e Event) { all = append(all, e.Kind) })
+
+	bus.Emit(Event{Kind: Attack})
+	bus.Emit(Event{Kind: Heal})
+	stopAttacks()
+	bus.Emit(Event{Kind: Attack})
+	stopAll()
+	bus.Emit(Event{Kind: Death})
+
+	if want := []Kind{Attack}; !reflect.DeepEqual(attacks, want) {
+		t.Errorf("подписка на Attack получила %v, ожидалось %v", attacks, want)
+	}
+	if want := []Kind{Attack, Heal, Attack}; !reflect.DeepEqual(all, want) {
+		t.Errorf("SubscribeAll получил %v, ожидалось %v", all, want)
+	}
+}
+
+func TestChildBusForwardsToParent(t *testing.T) {
+	parent := NewBus()
+	child := NewBus()
+	child.SubscribeAll(parent.Emit)
+
+	var got []Event
+	parent.Subscribe(Death, func(e Event) { got = append(got, e) })
+	var local int
+	child.Subscribe(Death, func(Event) { local++ })
+
+	child.Emit(Event{Kind: Death, Actor: "Гоблин"})
+	child.Emit(Event{Kind: Heal, Actor: "Гоблин"})
+	parent.Emit(Event{Kind: Death, Actor: "Волк"})
+
+	if len(got) != 2 || got[0].Actor != "Гоблин" || got[1].Actor != "Волк" {
+		t.Errorf("родительская шина получила %v", got)
+	}
+	if local != 1 {
+		t.Errorf("дочерняя шина получила %d событий смерти, ожидалось 1", local)
+	}
+}
+
+func TestNilBusIsSafe(t *testing.T) {
+	var bus *Bus
+	bus.Emit(Event{Kind: Attack})
+	bus.Subscribe(Attack, func(Event) {})()
+}
@@ -24,6 +24,16 @@ type AppModel struct {
 	chatModel       *ChatModel
 	pvpConnectModel *PvPConnectModel
 	pvpFightModel   *PvPFightModel
+	settingsModel   *SettingsModel
+	levelUpModel    *LevelUpModel
+	characterModel  *CharacterModel
+	lootModel       *LootModel
+	shopModel       *ShopModel
+	workshopModel   *WorkshopModel
+	creationModel   *CreationModel
+	campaignModel   *CampaignModel
+	dungeonModel    *DungeonModel
+	questModel      *QuestModel
 	quitting        bool
 	width           int
 	height          int
@@ -130,6 +140,46 @@ func (m AppModel) View() string {
 		} else {
 			content = "Бой..."
 		}
+	case ViewSettings:
+		if m.settingsModel != nil {
+			content = m.settingsModel.View()
+		}
+	case ViewLevelUp:
+		if m.levelUpModel != nil {
+			content = m.levelUpModel.View()
+		}
+	case ViewCharacter:
+		if m.characterModel != nil {
+			content = m.characterModel.View()
+		}
+	case ViewLoot:
+		if m.lootModel != nil {
+			content = m.lootModel.View()
+		}
+	case ViewShop:
+		if m.shopModel != nil {
+			content = m.shopModel.View()
+		}
+	case ViewWorkshop:
+		if m.workshopModel != nil {
+			content = m.workshopModel.View()
+		}
+	case ViewCreation:
+		if m.creationModel != nil {
+			content = m.creationModel.View()
+		}
+	case ViewCampaign:
+		if m.campaignModel != nil {
+			content = m.campaignModel.View()
+		}
+	case ViewDungeon:
+		if m.dungeonModel != nil {
+			content = m.dungeonModel.View()
+		}
+	case ViewQuests:
+		if m.questModel != nil {
+			content = m.questModel.View()
+		}
 	default:
 		content = "Загрузка..."
 	}
@@ -158,6 +208,36 @@ func (m *AppModel) applySizeToView(width, height int) {
 	if m.pvpFightModel != nil {
 		m.pvpFightModel.Width, m.pvpFightModel.Height = width, height
 	}
+	if m.settingsModel != nil {
+		m.settingsModel.Width, m.settingsModel.Height = width, height
+	}
+	if m.levelUpModel != nil {
+		m.levelUpModel.Width, m.levelUpModel.Height = width, height
+	}
+	if m.characterModel != nil {
+		m.characterModel.Width, m.characterModel.Height = width, height
+	}
+	if m.lootModel != nil {
+		m.lootModel.Width, m.lootModel.Height = width, height
+	}
+	if m.shopModel != nil {
+		m.shopModel.Width, m.shopModel.Height = width, height
+	}
+	if m.workshopModel != nil {
+		m.workshopModel.Width, m.workshopModel.Height = width, height
+	}
+	if m.creationModel != nil {
+		m.creationModel.Width, m.creationModel.Height = width, height
+	}
+	if m.campaignModel != nil {
+		m.campaignModel.Width, m.campaignModel.Height = width, height
+	}
+	if m.dungeonModel != nil {
+		m.dungeonModel.Width, m.dungeonModel.Height = width, height
+	}
+	if m.questModel != nil {
+		m.questModel.Width, m.questModel.Height = width, height
+	}
 }
 
 func (m *AppModel) handleWindowSize(msg tea.WindowSizeMsg) (AppModel, tea.Cmd) {
@@ -221,7 +301,7 @@ func (m *AppModel) handleKeyInput(msg tea.KeyMsg) (AppModel, tea.Cmd) {
 			m.currentView = ViewMainMenu
 			return *m, nil
 		}
-		if m.currentView == ViewFight {
+		if m.currentView == ViewFight || m.currentView == ViewLoot {
 			return m.delegateToCurrentView(msg)
 		}
 		if m.currentView == ViewMainMenu && (msg.Type == tea.KeyEsc || msg.String() == "esc") {
@@ -229,10 +309,9 @@ func (m *AppModel) handleKeyInput(msg tea.KeyMsg) (AppModel, tea.Cmd) {
 		}
 		if m.currentView == ViewMainMenu {
 			m.currentView = ViewExitConfirm
-		} else {
-			m.currentView = ViewMainMenu
+			return *m, nil
 		}
-		return *m, nil
+		return m.handleViewChange(ViewChangeMsg{View: ViewMainMenu})
 	}
 
 	if m.currentView == ViewExitConfirm {
@@ -257,12 +336,30 @@ func (m *AppModel) handleViewChange(msg ViewChangeMsg) (AppModel, tea.Cmd) {
 
 	if msg.View == ViewMainMenu {
 		sound.StopMusic()
+		m.gameCore.ExtendedGameManager.SetState(core.StateMenu)
+		if m.fightModel != nil && m.fightModel.gameOver {
+			m.fightModel = nil
+		}
+		if m.mainMenu != nil {
+			m.mainMenu.Refresh()
+		}
+	}
+	if msg.Outcome != core.OutcomeNone && m.mainMenu != nil {
+		m.mainMenu.LastOutcome = msg.Outcome
 	}
 
 	switch msg.View {
 	case ViewFight:
-
-		if m.fightModel == nil || m.fightModel.gameOver || m.fightModel.state == FightViewEnd {
+		if msg.Encounter != "" {
+			m.fightModel = NewFightModel(m.gameCore.ExtendedGameManager)
+			if m.fightModel != nil {
+				m.fightModel.Width, m.fightModel.Height = m.width, m.height
+				m.fightModel.StartScripted(msg.Encounter)
+				cmd = m.fightModel.Init()
+			}
+			break
+		}
+		if m.fightModel == nil || m.fightModel.gameOver || m.fightModel.state == FightViewEnd || m.fightModel.mode != core.StateMenu {
 			m.fightModel = NewFightModel(m.gameCore.ExtendedGameManager)
 			if m.fightModel != nil {
 				m.fightModel.Width, m.fightModel.Height = m.width, m.height
@@ -290,6 +387,39 @@ func (m *AppModel) handleViewChange(msg ViewChangeMsg) (AppModel, tea.Cmd) {
 			m.pvpConnectModel.Width, m.pvpConnectModel.Height = m.width, m.height
 			cmd = ConnectPvPWithFallbackCmd()
 		}
+	case ViewSettings:
+		m.settingsModel = NewSettingsModel(m.gameCore.ExtendedGameManager)
+		m.settingsModel.Width, m.settingsModel.Height = m.width, m.height
+	case ViewLevelUp:
+		m.levelUpModel = NewLevelUpModel(m.gameCore.ExtendedGameManager)
+		m.levelUpModel.Width, m.levelUpModel.Height = m.width, m.height
+		m.levelUpModel.origin = msg.Origin
+	case ViewCharacter:
+		m.characterModel = NewCharacterModel(m.gameCore.ExtendedGameManager)
+		m.characterModel.Width, m.characterModel.Height = m.width, m.height
+	case ViewLoot:
+		m.lootModel = NewLootModel(m.gameCore.ExtendedGameManager)
+		m.lootModel.Width, m.lootModel.Height = m.width, m.height
+		m.lootModel.origin = msg.Origin
+	case ViewShop:
+		m.shopModel = NewShopModel(m.gameCore.ExtendedGameManager)
+		m.shopModel.Width, m.shopModel.Height = m.width, m.height
+	case ViewWorkshop:
+		m.workshopModel = NewWorkshopModel(m.gameCore.ExtendedGameManager)
+		m.workshopModel.Width, m.workshopModel.Height = m.width, m.height
+	case ViewCreation:
+		m.creationModel = NewCreationModel(m.gameCore.ExtendedGameManager)
+		m.creationModel.Width, m.creationModel.Height = m.width, m.height
+	case ViewCampaign:
+		m.campaignModel = NewCampaignModel(m.gameCore.ExtendedGameManager)
+		m.campaignModel.Width, m.campaignModel.Height = m.width, m.height
+		cmd = m.campaignModel.Init()
+	case ViewDungeon:
+		m.dungeonModel = NewDungeonModel(m.gameCore.ExtendedGameManager)
+		m.dungeonModel.Width, m.dungeonModel.Height = m.width, m.height
+	case ViewQuests:
+		m.questModel = NewQuestModel(m.gameCore.ExtendedGameManager)
+		m.questModel.Width, m.questModel.Height = m.width, m.height
 	case ViewEULA:
 		if m.eulaModel == nil {
 			m.eulaModel = NewEULAModel(m.gameCore.ExtendedGameManager)
@@ -329,7 +459,8 @@ func (m *AppModel) handlePvPConnected(msg PvPConnectedMsg) (AppModel, tea.Cmd) {
 		m.currentView = ViewMainMenu
 		return *m, nil
 	}
-	m.pvpFightModel = NewPvPFightModel(msg.Session)
+	rng, _ := m.gameCore.ExtendedGameManager.Deps.NewBattleRNG()
+	m.pvpFightModel = NewPvPFightModel(msg.Session, rng)
 	if m.pvpFightModel != nil {
 		m.pvpFightModel.Width, m.pvpFightModel.Height = m.width, m.height
 		m.currentView = ViewPvPFight
@@ -379,6 +510,66 @@ func (m AppModel) delegateToCurrentView(msg tea.Msg) (AppModel, tea.Cmd) {
 			m.pvpFightModel, cmd = m.pvpFightModel.Update(msg)
 			return m, cmd
 		}
+	case ViewSettings:
+		if m.settingsModel != nil {
+			var cmd tea.Cmd
+			m.settingsModel, cmd = m.settingsModel.Update(msg)
+			return m, cmd
+		}
+	case ViewLevelUp:
+		if m.levelUpModel != nil {
+			var cmd tea.Cmd
+			m.levelUpModel, cmd = m.levelUpModel.Update(msg)
+			return m, cmd
+		}
+	case ViewCharacter:
+		if m.characterModel != nil {
+			var cmd tea.Cmd
+			m.characterModel, cmd = m.characterModel.Update(msg)
+			return m, cmd
+		}
+	case ViewLoot:
+		if m.lootModel != nil {
+			var cmd tea.Cmd
+			m.lootModel, cmd = m.lootModel.Update(msg)
+			return m, cmd
+		}
+	case ViewShop:
+		if m.shopModel != nil {
+			var cmd tea.Cmd
+			m.shopModel, cmd = m.shopModel.Update(msg)
+			return m, cmd
+		}
+	case ViewWorkshop:
+		if m.workshopModel != nil {
+			var cmd tea.Cmd
+			m.workshopModel, cmd = m.workshopModel.Update(msg)
+			return m, cmd
+		}
+	case ViewCreation:
+		if m.creationModel != nil {
+			var cmd tea.Cmd
+			m.creationModel, cmd = m.creationModel.Update(msg)
+			return m, cmd
+		}
+	case ViewCampaign:
+		if m.campaignModel != nil {
+			var cmd tea.Cmd
+			m.campaignModel, cmd = m.campaignModel.Update(msg)
+			return m, cmd
+		}
+	case ViewDungeon:
+		if m.dungeonModel != nil {
+			var cmd tea.Cmd
+			m.dungeonModel, cmd = m.dungeonModel.Update(msg)
+			return m, cmd
+		}
+	case ViewQuests:
+		if m.questModel != nil {
+			var cmd tea.Cmd
+			m.questModel, cmd = m.questModel.Update(msg)
+			return m, cmd
+		}
 	case ViewEULA:
 		if m.eulaModel != nil {
 			var cmd tea.Cmd
@@ -2,73 +2,83 @@ package game
 
 import (
 	"math/rand"
-	"time"
 
 	icharacter "MyGame/Interface"
 	"MyGame/Struct/Item"
+	"MyGame/combat"
+	"MyGame/events"
 )
 
 type TurnHandler struct {
-	rng *rand.Rand
+	rng      *rand.Rand
+	pipeline *combat.Pipeline
+	events   *events.Bus
 }
 
-func NewTurnHandler() *TurnHandler {
-	return &TurnHandler{rng: rand.New(rand.NewSource(time.Now().UnixNano()))}
+func NewTurnHandler(rng *rand.Rand) *TurnHandler {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(combat.DefaultSeed))
+	}
+	pipeline := combat.NewPipeline(rng)
+	pipeline.Use(combat.StageInjury, combat.InjuryModifier())
+	pipeline.Use(combat.StageWear, combat.WearModifier())
+	return &TurnHandler{rng: rng, pipeline: pipeline}
 }
 
-func (th *TurnHandler) ExecuteAttack(attacker, defender icharacter.ICharacter, attackPart, blockPart string) bool {
-	if attacker == nil || defender == nil || defender.GetHP() <= 0 {
-		return false
-	}
-	if attackPart != blockPart {
-		defender.TakeDamage(th.CalculateDamage(attacker, defender, attackPart))
-	}
-	return true
+func (th *TurnHandler) Pipeline() *combat.Pipeline {
+	return th.pipeline
 }
 
-func (th *TurnHandler) SimpleStrike(attacker, defender icharacter.ICharacter) (damage int, blocked bool) {
-	if attacker == nil || defender == nil || defender.GetHP() <= 0 {
-		return 0, false
-	}
-	attackPart := attacker.Hit()
-	blockPart := defender.Block()
-	if attackPart == blockPart {
-		return 0, true
+func (th *TurnHandler) SetEvents(bus *events.Bus) {
+	th.events = bus
+}
+
+func (th *TurnHandler) SimpleStrike(attacker, defender icharacter.ICharacter) combat.DamageResult {
+	if attacker == nil {
+		return combat.DamageResult{Defender: defender}
 	}
-	damage = th.CalculateDamage(attacker, defender, attackPart)
-	defender.TakeDamage(damage)
-	return damage, false
+	return th.StrikeAt(attacker, defender, attacker.Hit())
 }
 
-func (th *TurnHandler) CalculateDamage(attacker, defender icharacter.ICharacter, bodyPart string) int {
-	baseDamage := attacker.GetStrength() + int(attacker.GetAttack())
-	defense := int(defender.GetDefense())
+func (th *TurnHandler) StrikeAt(attacker, defender icharacter.ICharacter, attackPart string) combat.DamageResult {
+	return th.strike(attacker, defender, attackPart, 1.0)
+}
 
-	m := bodyPartMultiplier(bodyPart)
-	damage := float64(baseDamage)*m - float64(defense)*0.5
+const areaDamageFactor = 0.6
 
-	if th.rng != nil {
-		damage *= 0.9 + th.rng.Float64()*0.2
+func (th *TurnHandler) AreaStrike(attacker icharacter.ICharacter, defenders []icharacter.ICharacter) []combat.DamageResult {
+	results := make([]combat.DamageResult, 0, len(defenders))
+	if attacker == nil {
+		return results
 	}
-	if damage < 1 {
-		damage = 1
+	for _, defender := range defenders {
+		if defender == nil || !defender.IsAlive() {
+			continue
+		}
+		results = append(results, th.strike(attacker, defender, attacker.Hit(), areaDamageFactor))
 	}
-	return int(damage)
+	return results
 }
 
-func bodyPartMultiplier(bodyPart string) float64 {
-	switch bodyPart {
-	case "Голова":
-		return 1.5
-	case "Тело":
-		return 1.0
-	case "Правая нога", "Левая нога":
-		return 0.8
-	case "Правая рука", "Левая рука":
-		return 0.9
-	default:
-		return 1.0
+func (th *TurnHandler) strike(attacker, defender icharacter.ICharacter, attackPart string, factor float64) combat.DamageResult {
+	if attacker == nil || defender == nil || defender.GetHP() <= 0 {
+		return combat.DamageResult{Attacker: attacker, Defender: defender}
+	}
+	if combat.EffectsOf(attacker).Confused && th.rng.Float64() < combat.ConfusionSelfHit {
+		return th.ApplyHit(attacker, attacker, attackPart, factor*combat.ConfusedHitFactor)
+	}
+	if attackPart == defender.Block() {
+		res := combat.Blocked(attacker, defender, attackPart)
+		th.events.Emit(res.Event())
+		return res
 	}
+	return th.ApplyHit(attacker, defender, attackPart, factor)
+}
+
+func (th *TurnHandler) ApplyHit(attacker, defender icharacter.ICharacter, attackPart string, factor float64) combat.DamageResult {
+	res := th.pipeline.Resolve(attacker, defender, attackPart, factor)
+	th.events.Emit(res.Event())
+	return res
 }
 
 type ItemEffect struct {
@@ -77,6 +87,12 @@ type ItemEffect struct {
 	Attack   float32
 	Defense  float32
 	Duration int
+	Escape   bool
+	Cure     bool
+}
+
+type injuryCurer interface {
+	CureInjuries() int
 }
 
 type ItemEffectManager struct {
@@ -86,9 +102,11 @@ type ItemEffectManager struct {
 func NewItemEffectManager() *ItemEffectManager {
 	return &ItemEffectManager{
 		effects: map[int]ItemEffect{
-			19: {Health: 50},
-			22: {Mana: 30},
-			23: {Attack: 10, Duration: 3},
+			19:                  {Health: 50},
+			22:                  {Mana: 30},
+			23:                  {Attack: 10, Duration: 3},
+			Item.ReturnScrollID: {Escape: true},
+			Item.HealingSalveID: {Cure: true},
 		},
 	}
 }
@@ -113,19 +131,27 @@ func canUseInBattle(item *Item.Item) bool {
 	return item.Template.Type == Item.Potion || item.Template.Type == Item.Consumable
 }
 
+func (iem *ItemEffectManager) IsEscapeItem(item *Item.Item) bool {
+	if iem == nil || item == nil || item.Template == nil {
+		return false
+	}
+	return iem.effects[item.Template.ID].Escape
+}
+
 func (iem *ItemEffectManager) UseItem(player, enemy icharacter.ICharacter, item *Item.Item) bool {
 	_ = enemy
 	if player == nil || item == nil || item.Template == nil || !canUseInBattle(item) {
 		return false
 	}
 
+	amp := 1 + player.GetMagicAmp()
 	if iem != nil {
 		if eff, ok := iem.effects[item.Template.ID]; ok {
 			if eff.Health > 0 {
-				player.Heal(eff.Health)
+				player.Heal(eff.Health * amp)
 			}
 			if eff.Mana > 0 {
-				player.SetMana(player.GetMana() + eff.Mana)
+				player.SetMana(player.GetMana() + eff.Mana*amp)
 			}
 			if eff.Attack > 0 {
 				player.SetAttack(player.GetAttack() + eff.Attack)
@@ -133,6 +159,9 @@ func (iem *ItemEffectManager) UseItem(player, enemy icharacter.ICharacter, item
 			if eff.Defense > 0 {
 				player.SetDefense(player.GetDefense() + eff.Defense)
 			}
+			if curer, ok := player.(injuryCurer); ok && eff.Cure {
+				curer.CureInjuries()
+			}
 			return true
 		}
 	}
@@ -142,10 +171,10 @@ func (iem *ItemEffectManager) UseItem(player, enemy icharacter.ICharacter, item
 		return false
 	}
 	if v, ok := effects["health"]; ok && v > 0 {
-		player.Heal(v)
+		player.Heal(v * amp)
 	}
 	if v, ok := effects["mana"]; ok && v > 0 {
-		player.SetMana(player.GetMana() + v)
+		player.SetMana(player.GetMana() + v*amp)
 	}
 	if v, ok := effects["attack"]; ok && v > 0 {
 		player.SetAttack(player.GetAttack() + v)
@@ -163,6 +192,10 @@ func (iem *ItemEffectManager) GetItemEffectDescription(item *Item.Item) string {
 	if iem != nil {
 		if eff, ok := iem.effects[item.Template.ID]; ok {
 			switch {
+			case eff.Escape:
+				return "Гарантированный побег из боя"
+			case eff.Cure:
+				return "Излечивает все травмы"
 			case eff.Health > 0:
 				return "Восстанавливает 50 HP"
 			case eff.Mana > 0:
@@ -0,0 +1,170 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"MyGame/Struct/Campaign"
+	"MyGame/core"
+	"MyGame/game/ui"
+)
+
+type StoryTickMsg struct{}
+
+type CampaignModel struct {
+	gameManager   *core.ExtendedGameManager
+	chapter       *Campaign.Chapter
+	scene         *Campaign.Scene
+	displayedText string
+	typingIndex   int
+	isTyping      bool
+	typingSpeed   time.Duration
+	message       string
+	Width         int
+	Height        int
+}
+
+func NewCampaignModel(gameManager *core.ExtendedGameManager) *CampaignModel {
+	gameManager.SetState(core.StateStory)
+	m := &CampaignModel{
+		gameManager: gameManager,
+		typingSpeed: gameManager.GetConfig().TypewriterSpeed,
+		Width:       ui.MinWidth,
+		Height:      ui.MinHeight,
+	}
+	m.loadScene()
+	return m
+}
+
+func (m *CampaignModel) Init() tea.Cmd {
+	if !m.isTyping {
+		return nil
+	}
+	return m.tick()
+}
+
+func (m *CampaignModel) tick() tea.Cmd {
+	return tea.Tick(m.typingSpeed, func(time.Time) tea.Msg { return StoryTickMsg{} })
+}
+
+func (m *CampaignModel) loadScene() {
+	m.chapter, m.scene = m.gameManager.StoryScene()
+	m.displayedText = ""
+	m.typingIndex = 0
+	m.isTyping = m.scene != nil
+}
+
+func (m *CampaignModel) fullText() string {
+	if m.scene == nil {
+		return ""
+	}
+	return strings.Join(m.scene.Text, "\n")
+}
+
+func (m *CampaignModel) Update(msg tea.Msg) (*CampaignModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case StoryTickMsg:
+		if m.isTyping {
+			runes := []rune(m.fullText())
+			if m.typingIndex < len(runes) {
+				m.typingIndex++
+				m.displayedText = string(runes[:m.typingIndex])
+				return m, m.tick()
+			}
+			m.isTyping = false
+		}
+	case tea.KeyMsg:
+		if m.isTyping {
+			m.displayedText = m.fullText()
+			m.typingIndex = len([]rune(m.displayedText))
+			m.isTyping = false
+			return m, nil
+		}
+		switch msg.String() {
+		case "enter", " ":
+			return m, m.advance()
+		case "q", "й":
+			return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+		}
+	}
+	return m, nil
+}
+
+func (m *CampaignModel) advance() tea.Cmd {
+	if m.scene == nil {
+		return func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+	}
+	if m.scene.Battle != "" {
+		battle := m.scene.Battle
+		return func() tea.Msg { return ViewChangeMsg{View: ViewFight, Encounter: battle} }
+	}
+	m.message = ""
+	scene, err := m.gameManager.CompleteScene()
+	if err != nil {
+		m.message = fmt.Sprintf("❌ %v", err)
+	} else if scene.Gold > 0 {
+		m.message = fmt.Sprintf("💰 Получено золота: %d", scene.Gold)
+	}
+	m.loadScene()
+	if !m.isTyping {
+		return nil
+	}
+	return m.tick()
+}
+
+func (m *CampaignModel) View() string {
+	var b strings.Builder
+	width := max(m.Width, ui.MinWidth)
+
+	for i := 0; i < max(0, (m.Height-30)/3); i++ {
+		b.WriteString("\n")
+	}
+
+	titleStyle := ui.TitleStyle.Copy().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(ui.ColorBorder)).Padding(0, 1)
+	ui.CenteredLineBuilder(&b, titleStyle.Render("📜 КАМПАНИЯ"), width)
+	b.WriteString("\n")
+
+	if m.scene == nil {
+		ui.CenteredLineBuilder(&b, ui.SelectedStyle.Render("Кампания пройдена. Легенда о герое будет жить вечно."), width)
+		if m.message != "" {
+			b.WriteString("\n")
+			ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(m.message), width)
+		}
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.HelpStyle.Render("Enter В главное меню"), width)
+		return b.String()
+	}
+
+	statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorStats))
+	ui.CenteredLineBuilder(&b, statsStyle.Render(m.chapter.Title), width)
+	ui.CenteredLineBuilder(&b, ui.TitleStyle.Render(m.scene.Title), width)
+	b.WriteString("\n")
+
+	text := m.displayedText
+	if m.isTyping {
+		text += "█"
+	}
+	for _, line := range strings.Split(text, "\n") {
+		ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(line), width)
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(m.message), width)
+	}
+
+	help := "Enter Пропустить"
+	if !m.isTyping {
+		help = "Enter Далее  │  Q Выход"
+		if m.scene.Battle != "" {
+			help = "Enter В бой  │  Q Выход"
+		}
+	}
+	b.WriteString("\n")
+	ui.CenteredLineBuilder(&b, ui.HelpStyle.Render(help), width)
+	return b.String()
+}
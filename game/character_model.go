@@ -0,0 +1,334 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"MyGame/Struct/Character"
+	"MyGame/Struct/Item"
+	"MyGame/combat"
+	"MyGame/core"
+	"MyGame/game/ui"
+)
+
+type characterMode int
+
+const (
+	characterModeEdit characterMode = iota
+	characterModeConfirm
+	characterModeRespec
+	characterModeGear
+)
+
+type CharacterModel struct {
+	gameManager *core.ExtendedGameManager
+	pending     Character.Allocation
+	selected    int
+	gear        int
+	mode        characterMode
+	message     string
+	Width       int
+	Height      int
+}
+
+func NewCharacterModel(gameManager *core.ExtendedGameManager) *CharacterModel {
+	return &CharacterModel{
+		gameManager: gameManager,
+		Width:       ui.MinWidth,
+		Height:      ui.MinHeight,
+	}
+}
+
+func (m *CharacterModel) Update(msg tea.Msg) (*CharacterModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	player := m.gameManager.GetPlayer()
+	if player == nil {
+		return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+	}
+
+	switch m.mode {
+	case characterModeConfirm:
+		switch keyMsg.String() {
+		case "y", "Y", "д", "Д", "enter":
+			if err := player.Allocate(m.pending); err != nil {
+				m.message = fmt.Sprintf("❌ %v", err)
+			} else {
+				m.message = fmt.Sprintf("✅ Распределено очков: %d", m.pending.Total())
+				m.pending = Character.Allocation{}
+				m.gameManager.AutoSave()
+			}
+			m.mode = characterModeEdit
+		case "n", "N", "н", "Н":
+			m.mode = characterModeEdit
+		}
+		return m, nil
+	case characterModeRespec:
+		switch keyMsg.String() {
+		case "y", "Y", "д", "Д", "enter":
+			cost := player.RespecCost()
+			if err := player.Respec(); err != nil {
+				m.message = fmt.Sprintf("❌ %v", err)
+			} else {
+				m.message = fmt.Sprintf("♻️ Характеристики сброшены за %d зол., свободных очков: %d", cost, player.StatPoints)
+				m.pending = Character.Allocation{}
+				m.gameManager.AutoSave()
+			}
+			m.mode = characterModeEdit
+		case "n", "N", "н", "Н":
+			m.mode = characterModeEdit
+		}
+		return m, nil
+	case characterModeGear:
+		return m.updateGear(keyMsg, player)
+	}
+
+	attr := Character.Attributes[m.selected]
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.selected > 0 {
+			m.selected--
+		}
+	case "down", "j":
+		if m.selected < len(Character.Attributes)-1 {
+			m.selected++
+		}
+	case "right", "l", "+":
+		if m.pending.Total() < player.StatPoints {
+			m.pending[attr]++
+		}
+	case "left", "h", "-":
+		if m.pending[attr] > 0 {
+			m.pending[attr]--
+		}
+	case "u", "г", "backspace":
+		m.pending = Character.Allocation{}
+		m.message = "↩️ Изменения отменены"
+	case "enter", " ":
+		if m.pending.Total() == 0 {
+			m.message = "Сначала распределите очки стрелками ←→"
+			return m, nil
+		}
+		m.mode = characterModeConfirm
+	case "r", "к":
+		m.mode = characterModeRespec
+	case "e", "у":
+		m.mode = characterModeGear
+		m.gear = 0
+		m.message = ""
+	case "q":
+		return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+	}
+	return m, nil
+}
+
+type gearEntry struct {
+	slot      Item.EquipmentSlot
+	inventory int
+	item      *Item.Item
+}
+
+func gearEntries(player *Character.Character) []gearEntry {
+	entries := make([]gearEntry, 0)
+	for _, slot := range player.Equipment.GetAllEquipmentSlots() {
+		entries = append(entries, gearEntry{slot: slot, inventory: -1, item: player.Equipment.GetItem(slot)})
+	}
+	for i, item := range player.GetInventory().GetItems() {
+		if item != nil && item.Template != nil && item.Template.Slot != Item.SlotNone {
+			entries = append(entries, gearEntry{slot: item.Template.Slot, inventory: i, item: item})
+		}
+	}
+	return entries
+}
+
+func (m *CharacterModel) updateGear(keyMsg tea.KeyMsg, player *Character.Character) (*CharacterModel, tea.Cmd) {
+	entries := gearEntries(player)
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.gear > 0 {
+			m.gear--
+		}
+	case "down", "j":
+		if m.gear < len(entries)-1 {
+			m.gear++
+		}
+	case "enter", " ":
+		entry := entries[m.gear]
+		var err error
+		switch {
+		case entry.inventory >= 0:
+			err = player.EquipFromInventory(entry.inventory)
+			if err == nil {
+				m.message = fmt.Sprintf("✅ Надето: %s", entry.item.GetFullName())
+			}
+		case entry.item != nil:
+			err = player.UnequipItem(entry.slot)
+			if err == nil {
+				m.message = fmt.Sprintf("🎒 Снято: %s", entry.item.GetFullName())
+			}
+		}
+		if err != nil {
+			m.message = fmt.Sprintf("❌ %v", err)
+		} else {
+			m.gameManager.AutoSave()
+		}
+		m.gear = min(m.gear, len(gearEntries(player))-1)
+	case "e", "у", "q", "backspace":
+		m.mode = characterModeEdit
+	}
+	return m, nil
+}
+
+func (m *CharacterModel) renderGear(b *strings.Builder, player *Character.Character, width int) {
+	ui.CenteredLineBuilder(b, ui.TitleStyle.Render("🛡️ СНАРЯЖЕНИЕ"), width)
+	entries := gearEntries(player)
+	for i, entry := range entries {
+		if i == len(player.Equipment.GetAllEquipmentSlots()) {
+			b.WriteString("\n")
+			ui.CenteredLineBuilder(b, ui.NormalStyle.Render("В инвентаре:"), width)
+		}
+		line := "— пусто —"
+		if entry.item != nil {
+			line = ui.RarityStyle(entry.item.Rarity).Render(fmt.Sprintf("%s (ур. %d, прочность %d/%d)",
+				entry.item.GetFullName(), entry.item.Level, entry.item.Durability, entry.item.MaxDurability))
+			if entry.item.IsBroken() {
+				line += ui.DangerStyle.Render(" сломан")
+			} else if entry.item.IsWorn() {
+				line += ui.WarningStyle.Render(" изношен")
+			}
+		}
+		if entry.inventory < 0 {
+			line = fmt.Sprintf("%-10s %s", player.Equipment.GetSlotName(entry.slot)+":", line)
+		}
+		ui.CenteredLineBuilder(b, ui.RenderMenuItem(i == m.gear, line), width)
+	}
+
+	sets := player.Equipment.ActiveSets()
+	if len(sets) > 0 {
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(b, ui.TitleStyle.Render("Комплекты"), width)
+	}
+	active := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorSuccess))
+	for _, status := range sets {
+		ui.CenteredLineBuilder(b, ui.NormalStyle.Render(fmt.Sprintf("«%s»: %d/%d", status.Set.Name, status.Pieces, len(status.Set.Pieces))), width)
+		for _, bonus := range status.Set.Bonuses {
+			line := fmt.Sprintf("%d предм.: %s", bonus.Pieces, bonus)
+			if status.Pieces >= bonus.Pieces {
+				ui.CenteredLineBuilder(b, active.Render("● "+line), width)
+			} else {
+				ui.CenteredLineBuilder(b, ui.HelpStyle.Render("○ "+line), width)
+			}
+		}
+	}
+}
+
+func (m *CharacterModel) View() string {
+	var b strings.Builder
+	width := max(m.Width, ui.MinWidth)
+
+	for i := 0; i < max(0, (m.Height-30)/3); i++ {
+		b.WriteString("\n")
+	}
+
+	titleStyle := ui.TitleStyle.Copy().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(ui.ColorBorder)).Padding(0, 1)
+	ui.CenteredLineBuilder(&b, titleStyle.Render("📈 ПЕРСОНАЖ — ХАРАКТЕРИСТИКИ"), width)
+	b.WriteString("\n")
+
+	player := m.gameManager.GetPlayer()
+	if player == nil {
+		ui.CenteredLineBuilder(&b, ui.DangerStyle.Render("Игрок не создан"), width)
+		return b.String()
+	}
+
+	statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorStats))
+	ui.CenteredLineBuilder(&b, statsStyle.Render(fmt.Sprintf("%s — уровень %d, опыт %d/%d  │  Свободных очков: %d",
+		player.GetName(), player.GetLevel(), player.XP, m.gameManager.GetConfig().XPForLevel(player.GetLevel()),
+		player.StatPoints-m.pending.Total())), width)
+	b.WriteString("\n")
+
+	base := map[Character.Attribute]int{
+		Character.AttrHP:           player.GetBaseHP(),
+		Character.AttrStrength:     player.GetBaseStrength(),
+		Character.AttrAgility:      player.GetBaseAgility(),
+		Character.AttrIntelligence: player.GetBaseIntelligence(),
+	}
+	for i, attr := range Character.Attributes {
+		line := fmt.Sprintf("%-10s %4d", attr, base[attr])
+		if n := m.pending[attr]; n > 0 {
+			line += fmt.Sprintf("  +%d", n)
+			if attr == Character.AttrHP {
+				line += fmt.Sprintf(" (+%d HP)", n*Character.HPPerPoint)
+			}
+		}
+		ui.CenteredLineBuilder(&b, ui.RenderMenuItem(i == m.selected, line), width)
+	}
+	b.WriteString("\n")
+
+	if m.mode == characterModeGear {
+		m.renderGear(&b, player, width)
+		if m.message != "" {
+			b.WriteString("\n")
+			ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(m.message), width)
+		}
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.HelpStyle.Render("↑↓ Выбор  │  Enter Надеть/снять  │  E Назад"), width)
+		return b.String()
+	}
+
+	preview := player.Preview(m.pending)
+	rows := []struct {
+		name         string
+		before, next string
+	}{
+		{"Максимум HP", fmt.Sprint(player.GetMaxHP()), fmt.Sprint(preview.GetMaxHP())},
+		{"Сила удара", fmt.Sprint(player.GetStrength()), fmt.Sprint(preview.GetStrength())},
+		{"Шанс крита", percentText(player.GetCritChance()), percentText(preview.GetCritChance())},
+		{"Уклонение", percentText(player.GetDodgeChance()), percentText(preview.GetDodgeChance())},
+		{"Инициатива", fmt.Sprintf("%.0f", combat.Speed(player.GetAgility(), player.GetAttackSpeed())),
+			fmt.Sprintf("%.0f", combat.Speed(preview.GetAgility(), preview.GetAttackSpeed()))},
+		{"Усиление магии", percentText(player.GetMagicAmp()), percentText(preview.GetMagicAmp())},
+	}
+	changed := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorSuccess)).Bold(true)
+	for _, row := range rows {
+		line := statsStyle.Render(fmt.Sprintf("%-15s %6s", row.name, row.before))
+		if row.next != row.before {
+			line += changed.Render(" → " + row.next)
+		}
+		ui.CenteredLineBuilder(&b, line, width)
+	}
+
+	switch m.mode {
+	case characterModeConfirm:
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(fmt.Sprintf("Вложить %d очк.? Y — подтвердить, N — вернуться", m.pending.Total())), width)
+	case characterModeRespec:
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(fmt.Sprintf("Сбросить %d вложенных очков за %d зол.? Y — да, N — нет",
+			player.Allocated.Total(), player.RespecCost())), width)
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(m.message), width)
+	}
+
+	b.WriteString("\n")
+	ui.CenteredLineBuilder(&b, ui.HelpStyle.Render("↑↓ Выбор  │  ←→ Очко  │  Enter Подтвердить  │  U Отменить  │  R Сброс за опыт  │  E Снаряжение  │  ESC Назад"), width)
+	return b.String()
+}
+
+func itemDetail(player *Character.Character, item *Item.Item) string {
+	if player == nil || player.Equipment == nil {
+		return item.GetDetailedDescription(0)
+	}
+	return item.GetDetailedDescription(player.Equipment.SetPieces(item.Set()))
+}
+
+func percentText(v float32) string {
+	return fmt.Sprintf("%.1f%%", v*100)
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	icharacter "MyGame/Interface"
+	"MyGame/Struct/Bestiary"
+	"MyGame/Struct/Character"
+)
+
+type CombatSide int
+
+const (
+	SideParty CombatSide = iota
+	SideEnemies
+)
+
+type Combatant struct {
+	Char *Character.Character
+	Def  *Bestiary.Enemy
+	AI   *EnemyAI
+	Side CombatSide
+}
+
+func (c *Combatant) IsPlayerControlled() bool {
+	return c.AI == nil
+}
+
+func (c *Combatant) IsAlive() bool {
+	return c != nil && c.Char != nil && c.Char.IsAlive()
+}
+
+func livingCombatants(list []*Combatant) []*Combatant {
+	out := make([]*Combatant, 0, len(list))
+	for _, c := range list {
+		if c.IsAlive() {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func combatantCharacters(list []*Combatant) []icharacter.ICharacter {
+	out := make([]icharacter.ICharacter, 0, len(list))
+	for _, c := range list {
+		out = append(out, c.Char)
+	}
+	return out
+}
@@ -0,0 +1,258 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"MyGame/Struct/Character"
+	"MyGame/combat"
+	"MyGame/core"
+	"MyGame/game/ui"
+)
+
+type creationStep int
+
+const (
+	creationStepName creationStep = iota
+	creationStepClass
+	creationStepPoints
+	creationStepKit
+)
+
+type CreationModel struct {
+	gameManager *core.ExtendedGameManager
+	step        creationStep
+	name        []rune
+	class       int
+	attr        int
+	points      Character.Allocation
+	message     string
+	cache       creationPreview
+	Width       int
+	Height      int
+}
+
+type creationPreview struct {
+	name   string
+	class  int
+	points Character.Allocation
+	player *Character.Character
+	err    error
+}
+
+func NewCreationModel(gameManager *core.ExtendedGameManager) *CreationModel {
+	return &CreationModel{
+		gameManager: gameManager,
+		name:        []rune(gameManager.GetConfig().PlayerName),
+		Width:       ui.MinWidth,
+		Height:      ui.MinHeight,
+	}
+}
+
+func (m *CreationModel) create() (*Character.Character, error) {
+	return Character.Create(string(m.name), Character.Classes[m.class], m.points)
+}
+
+func (m *CreationModel) preview() (*Character.Character, error) {
+	name := string(m.name)
+	if m.cache.player == nil && m.cache.err == nil || m.cache.name != name || m.cache.class != m.class || m.cache.points != m.points {
+		player, err := m.create()
+		m.cache = creationPreview{name: name, class: m.class, points: m.points, player: player, err: err}
+	}
+	return m.cache.player, m.cache.err
+}
+
+func (m *CreationModel) Update(msg tea.Msg) (*CreationModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	m.message = ""
+	if m.step == creationStepName {
+		return m.updateName(keyMsg)
+	}
+	if keyMsg.Type == tea.KeyBackspace {
+		m.step--
+		return m, nil
+	}
+
+	switch m.step {
+	case creationStepClass:
+		switch keyMsg.String() {
+		case "up", "k":
+			if m.class > 0 {
+				m.class--
+			}
+		case "down", "j":
+			if m.class < len(Character.Classes)-1 {
+				m.class++
+			}
+		case "enter", " ":
+			m.step = creationStepPoints
+		}
+	case creationStepPoints:
+		attr := Character.Attributes[m.attr]
+		switch keyMsg.String() {
+		case "up", "k":
+			if m.attr > 0 {
+				m.attr--
+			}
+		case "down", "j":
+			if m.attr < len(Character.Attributes)-1 {
+				m.attr++
+			}
+		case "right", "l", "+":
+			if m.points.Total() < Character.CreationPoints {
+				m.points[attr]++
+			}
+		case "left", "h", "-":
+			if m.points[attr] > 0 {
+				m.points[attr]--
+			}
+		case "enter", " ":
+			m.step = creationStepKit
+		}
+	case creationStepKit:
+		switch keyMsg.String() {
+		case "enter", "y", "Y", "д", "Д":
+			player, err := m.create()
+			if err != nil {
+				m.message = fmt.Sprintf("❌ %v", err)
+				return m, nil
+			}
+			m.gameManager.ResetProgress()
+			m.gameManager.UpdatePlayer(player)
+			m.gameManager.AutoSave()
+			return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+		}
+	}
+	return m, nil
+}
+
+func (m *CreationModel) updateName(keyMsg tea.KeyMsg) (*CreationModel, tea.Cmd) {
+	switch keyMsg.Type {
+	case tea.KeyBackspace:
+		if len(m.name) > 0 {
+			m.name = m.name[:len(m.name)-1]
+		}
+	case tea.KeySpace:
+		m.name = append(m.name, ' ')
+	case tea.KeyRunes:
+		m.name = append(m.name, FixRunesForWindows(keyMsg.Runes)...)
+		if utf8.RuneCountInString(string(m.name)) > Character.MaxNameLength {
+			m.name = m.name[:Character.MaxNameLength]
+		}
+	case tea.KeyEnter:
+		name, err := Character.ValidateName(string(m.name))
+		if err != nil {
+			m.message = fmt.Sprintf("❌ %v", err)
+			return m, nil
+		}
+		m.name = []rune(name)
+		m.step = creationStepClass
+	}
+	return m, nil
+}
+
+func (m *CreationModel) View() string {
+	var b strings.Builder
+	width := max(m.Width, ui.MinWidth)
+
+	for i := 0; i < max(0, (m.Height-30)/3); i++ {
+		b.WriteString("\n")
+	}
+
+	titleStyle := ui.TitleStyle.Copy().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(ui.ColorBorder)).Padding(0, 1)
+	ui.CenteredLineBuilder(&b, titleStyle.Render("🌟 НОВАЯ ИГРА"), width)
+	b.WriteString("\n")
+
+	steps := []string{"Имя", "Класс", "Очки", "Снаряжение"}
+	for i, name := range steps {
+		if creationStep(i) == m.step {
+			steps[i] = ui.SelectedStyle.Render("[ " + name + " ]")
+		} else {
+			steps[i] = ui.NormalStyle.Render("  " + name + "  ")
+		}
+	}
+	ui.CenteredLineBuilder(&b, strings.Join(steps, " → "), width)
+	b.WriteString("\n")
+
+	statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorStats))
+	help := ""
+	switch m.step {
+	case creationStepName:
+		ui.CenteredLineBuilder(&b, ui.NormalStyle.Render("Как зовут героя?"), width)
+		ui.CenteredLineBuilder(&b, ui.SelectedStyle.Render(fmt.Sprintf("> %s_", string(m.name))), width)
+		ui.CenteredLineBuilder(&b, ui.HelpStyle.Render(fmt.Sprintf("%d/%d символов", len(m.name), Character.MaxNameLength)), width)
+		help = "Буквы, цифры, пробел и дефис  │  Enter Далее  │  ESC Отмена"
+	case creationStepClass:
+		for i, class := range Character.Classes {
+			line := fmt.Sprintf("%-10s HP %3d  Сила %2d  Ловкость %2d  Интеллект %2d",
+				class.Name, class.HP, class.Strength, class.Agility, class.Intelligence)
+			ui.CenteredLineBuilder(&b, ui.RenderMenuItem(i == m.class, line), width)
+		}
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(Character.Classes[m.class].Description), width)
+		b.WriteString("\n")
+		m.renderDerived(&b, statsStyle, width)
+		help = "↑↓ Класс  │  Enter Далее  │  Backspace Назад"
+	case creationStepPoints:
+		stats := Character.Classes[m.class].Stats(m.points)
+		ui.CenteredLineBuilder(&b, statsStyle.Render(fmt.Sprintf("Свободных очков: %d из %d (необязательно)",
+			Character.CreationPoints-m.points.Total(), Character.CreationPoints)), width)
+		for i, attr := range Character.Attributes {
+			line := fmt.Sprintf("%-10s %4d", attr, stats[attr])
+			if n := m.points[attr]; n > 0 {
+				line += fmt.Sprintf("  (+%d)", n)
+			}
+			ui.CenteredLineBuilder(&b, ui.RenderMenuItem(i == m.attr, line), width)
+		}
+		b.WriteString("\n")
+		m.renderDerived(&b, statsStyle, width)
+		help = "↑↓ Выбор  │  ←→ Очко  │  Enter Далее  │  Backspace Назад"
+	case creationStepKit:
+		player, err := m.preview()
+		if err != nil {
+			ui.CenteredLineBuilder(&b, ui.DangerStyle.Render(err.Error()), width)
+			break
+		}
+		ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(fmt.Sprintf("%s, %s", player.GetName(), Character.Classes[m.class].Name)), width)
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.TitleStyle.Render("Надето"), width)
+		for _, slot := range player.Equipment.GetAllEquipmentSlots() {
+			if item := player.Equipment.GetItem(slot); item != nil {
+				ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(fmt.Sprintf("%s: %s", player.Equipment.GetSlotName(slot), item.GetFullName())), width)
+			}
+		}
+		ui.CenteredLineBuilder(&b, ui.TitleStyle.Render("В сумке"), width)
+		for _, item := range player.GetInventory().GetItems() {
+			ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(item.GetFullName()), width)
+		}
+		b.WriteString("\n")
+		m.renderDerived(&b, statsStyle, width)
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render("Текущий прогресс будет потерян. Начать? Enter/Y — да"), width)
+		help = "Enter Начать игру  │  Backspace Назад  │  ESC Отмена"
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(m.message), width)
+	}
+	b.WriteString("\n")
+	ui.CenteredLineBuilder(&b, ui.HelpStyle.Render(help), width)
+	return b.String()
+}
+
+func (m *CreationModel) renderDerived(b *strings.Builder, style lipgloss.Style, width int) {
+	player, err := m.preview()
+	if err != nil {
+		return
+	}
+	ui.CenteredLineBuilder(b, style.Render(fmt.Sprintf("HP %d  │  Атака %.1f  │  Защита %.1f  │  Крит %s  │  Уклонение %s  │  Инициатива %.0f",
+		player.GetMaxHP(), player.GetAttack(), player.GetDefense(), percentText(player.GetCritChance()),
+		percentText(player.GetDodgeChance()), combat.Speed(player.GetAgility(), player.GetAttackSpeed()))), width)
+}
@@ -0,0 +1,40 @@
+package game
+
+import (
+	"MyGame/Struct/Bestiary"
+	"MyGame/Struct/Character"
+	"MyGame/Struct/Item"
+	"MyGame/config"
+)
+
+func adjustedEnemy(def *Bestiary.Enemy, d config.DifficultyProfile) *Bestiary.Enemy {
+	if def == nil {
+		return nil
+	}
+	scaled := def.Scaled(d.EnemyHP, d.EnemyStrength, d.EnemyAgility)
+	if d.EnemyAI != "" {
+		scaled.AI = Bestiary.AIProfile(d.EnemyAI)
+	}
+	return scaled
+}
+
+func setHealthPotions(char *Character.Character, count int) {
+	potion := Item.CreateHealthPotion()
+	if char == nil || potion == nil {
+		return
+	}
+	id := potion.Template.ID
+	for char.Inventory.FindItemByID(id) != nil {
+		if _, err := char.Inventory.RemoveItem(id); err != nil {
+			break
+		}
+	}
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			potion = Item.CreateHealthPotion()
+		}
+		if err := char.Inventory.AddItem(potion); err != nil {
+			return
+		}
+	}
+}
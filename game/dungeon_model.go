@@ -0,0 +1,192 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"MyGame/Struct/Dungeon"
+	"MyGame/core"
+	"MyGame/game/ui"
+)
+
+var roomSymbols = map[Dungeon.RoomKind]string{
+	Dungeon.RoomEmpty:    "·",
+	Dungeon.RoomStart:    "◇",
+	Dungeon.RoomBattle:   "⚔",
+	Dungeon.RoomTreasure: "$",
+	Dungeon.RoomTrap:     "^",
+	Dungeon.RoomRest:     "+",
+	Dungeon.RoomExit:     ">",
+}
+
+type DungeonModel struct {
+	gameManager *core.ExtendedGameManager
+	dungeon     *Dungeon.Dungeon
+	message     string
+	Width       int
+	Height      int
+}
+
+func NewDungeonModel(gameManager *core.ExtendedGameManager) *DungeonModel {
+	m := &DungeonModel{
+		gameManager: gameManager,
+		Width:       ui.MinWidth,
+		Height:      ui.MinHeight,
+	}
+	dungeon, err := gameManager.EnterDungeon()
+	if err != nil {
+		m.message = fmt.Sprintf("❌ %v", err)
+	}
+	m.dungeon = dungeon
+	return m
+}
+
+func (m *DungeonModel) Update(msg tea.Msg) (*DungeonModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.dungeon == nil || m.dungeon.Failed {
+		return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+	}
+	switch keyMsg.String() {
+	case "up", "k":
+		return m, m.move(0, -1)
+	case "down", "j":
+		return m, m.move(0, 1)
+	case "left", "h":
+		return m, m.move(-1, 0)
+	case "right", "l":
+		return m, m.move(1, 0)
+	case "enter", " ":
+		return m, m.interact()
+	case "q", "й":
+		return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+	}
+	return m, nil
+}
+
+func (m *DungeonModel) move(dx, dy int) tea.Cmd {
+	room, err := m.dungeon.Move(dx, dy)
+	if err != nil {
+		m.message = fmt.Sprintf("🧱 %v", err)
+		return nil
+	}
+	m.message = m.gameManager.ExploreRoom(room)
+	if room.Kind == Dungeon.RoomBattle && !room.Cleared {
+		m.message = "⚔️ В комнате враги! Enter — в бой, шаг назад — отступить"
+	}
+	return nil
+}
+
+func (m *DungeonModel) battle(room *Dungeon.Room) tea.Cmd {
+	if room.Kind != Dungeon.RoomBattle || room.Cleared {
+		return nil
+	}
+	encounter := room.Encounter
+	return func() tea.Msg { return ViewChangeMsg{View: ViewFight, Encounter: encounter} }
+}
+
+func (m *DungeonModel) interact() tea.Cmd {
+	room := m.dungeon.Current()
+	if room == nil {
+		return nil
+	}
+	if room.Kind == Dungeon.RoomExit {
+		if err := m.gameManager.Descend(); err != nil {
+			m.message = fmt.Sprintf("❌ %v", err)
+			return nil
+		}
+		m.dungeon = m.gameManager.Dungeon
+		m.message = fmt.Sprintf("🪜 Вы спускаетесь на уровень %d", m.dungeon.Depth)
+		return nil
+	}
+	if msg := m.gameManager.ExploreRoom(room); msg != "" {
+		m.message = msg
+	}
+	return m.battle(room)
+}
+
+func (m *DungeonModel) cell(x, y int) string {
+	room := m.dungeon.Room(x, y)
+	switch {
+	case x == m.dungeon.X && y == m.dungeon.Y:
+		return ui.SelectedStyle.Render("[@]")
+	case room == nil || !m.dungeon.Known(x, y):
+		return "   "
+	case !room.Visited:
+		return ui.HelpStyle.Render("[?]")
+	case room.Cleared && room.Kind != Dungeon.RoomExit && room.Kind != Dungeon.RoomStart:
+		return ui.NormalStyle.Render("[ ]")
+	case room.Kind == Dungeon.RoomBattle || room.Kind == Dungeon.RoomTrap:
+		return ui.DangerStyle.Render("[" + roomSymbols[room.Kind] + "]")
+	}
+	return ui.TitleStyle.Render("[" + roomSymbols[room.Kind] + "]")
+}
+
+func (m *DungeonModel) View() string {
+	var b strings.Builder
+	width := max(m.Width, ui.MinWidth)
+
+	for i := 0; i < max(0, (m.Height-30)/3); i++ {
+		b.WriteString("\n")
+	}
+
+	titleStyle := ui.TitleStyle.Copy().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(ui.ColorBorder)).Padding(0, 1)
+	ui.CenteredLineBuilder(&b, titleStyle.Render("🕯️ ПОДЗЕМЕЛЬЕ"), width)
+	b.WriteString("\n")
+
+	if m.dungeon == nil {
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(m.message), width)
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.HelpStyle.Render("Любая клавиша — в главное меню"), width)
+		return b.String()
+	}
+
+	player := m.gameManager.GetPlayer()
+	visited, total := m.dungeon.Explored()
+	statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorStats))
+	ui.CenteredLineBuilder(&b, statsStyle.Render(fmt.Sprintf("Уровень %d  │  Исследовано %d/%d  │  💰 %d",
+		m.dungeon.Depth, visited, total, player.Gold)), width)
+	b.WriteString(ui.RenderBattleHpLine(player.GetName(), m.dungeon.HP, player.GetMaxHP(), width) + "\n")
+	b.WriteString("\n")
+
+	for y := 0; y < Dungeon.Height; y++ {
+		row := make([]string, Dungeon.Width)
+		for x := range row {
+			row[x] = m.cell(x, y)
+		}
+		ui.CenteredLineByVisibleWidth(&b, strings.Join(row, ""), Dungeon.Width*3, width)
+	}
+	b.WriteString("\n")
+
+	room := m.dungeon.Current()
+	ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(room.Kind.String()), width)
+	if m.message != "" {
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(m.message), width)
+	}
+	if m.dungeon.Failed {
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.DangerStyle.Render("💀 Вы повержены. Вас вынесли на поверхность, а подземелье обрушилось."), width)
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.HelpStyle.Render("Любая клавиша — в главное меню"), width)
+		return b.String()
+	}
+
+	b.WriteString("\n")
+	ui.CenteredLineBuilder(&b, ui.HelpStyle.Render("@ вы  ⚔ враги  $ сокровище  ^ ловушка  + привал  > спуск  ? неизвестно"), width)
+	help := "↑↓←→ Ходить  │  Q Выход"
+	switch {
+	case room.Kind == Dungeon.RoomExit:
+		help = "↑↓←→ Ходить  │  Enter Спуститься  │  Q Выход"
+	case room.Kind == Dungeon.RoomBattle && !room.Cleared:
+		help = "↑↓←→ Отступить  │  Enter В бой  │  Q Выход"
+	case room.Kind == Dungeon.RoomTreasure && !room.Cleared:
+		help = "↑↓←→ Ходить  │  Enter Подобрать  │  Q Выход"
+	}
+	ui.CenteredLineBuilder(&b, ui.HelpStyle.Render(help), width)
+	return b.String()
+}
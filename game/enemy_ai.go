@@ -0,0 +1,83 @@
+package game
+
+import (
+	"math/rand"
+
+	"MyGame/Struct/Bestiary"
+	"MyGame/Struct/Character"
+	"MyGame/Struct/Item"
+)
+
+type EnemyAI struct {
+	profile Bestiary.AIProfile
+	rng     *rand.Rand
+}
+
+func NewEnemyAI(profile Bestiary.AIProfile, rng *rand.Rand) *EnemyAI {
+	if profile == "" {
+		profile = Bestiary.AIBalanced
+	}
+	return &EnemyAI{profile: profile, rng: rng}
+}
+
+func (ai *EnemyAI) Profile() Bestiary.AIProfile {
+	return ai.profile
+}
+
+func (ai *EnemyAI) healThreshold() float64 {
+	switch ai.profile {
+	case Bestiary.AIAggressive:
+		return 0
+	case Bestiary.AIDefensive:
+		return 0.4
+	default:
+		return 0.25
+	}
+}
+
+func (ai *EnemyAI) ChooseAttackPart(self *Character.Character) string {
+	if ai.profile == Bestiary.AIAggressive && ai.rng != nil && ai.rng.Intn(2) == 0 {
+		return "Голова"
+	}
+	return self.Hit()
+}
+
+func (ai *EnemyAI) ChooseHealingItem(self *Character.Character, iem *ItemEffectManager) *Item.Item {
+	if self == nil || iem == nil || self.GetMaxHP() <= 0 {
+		return nil
+	}
+	hpPercent := float64(self.GetHP()) / float64(self.GetMaxHP())
+	if hpPercent >= ai.healThreshold() {
+		return nil
+	}
+	for _, item := range iem.GetUsableItems(self.GetInventory().GetItems()) {
+		if item.Health > 0 || item.Template.BaseHealth > 0 {
+			return item
+		}
+	}
+	return nil
+}
+
+func (ai *EnemyAI) ChooseTarget(opponents []*Combatant) *Combatant {
+	living := livingCombatants(opponents)
+	if len(living) == 0 {
+		return nil
+	}
+	if ai.profile == Bestiary.AIAggressive || ai.rng == nil {
+		weakest := living[0]
+		for _, c := range living[1:] {
+			if c.Char.GetHP() < weakest.Char.GetHP() {
+				weakest = c
+			}
+		}
+		return weakest
+	}
+	return living[ai.rng.Intn(len(living))]
+}
+
+func (ai *EnemyAI) WantsAreaAttack(self *Combatant, opponents []*Combatant) bool {
+	if self == nil || self.Def == nil || !self.Def.AreaAttack || ai.rng == nil {
+		return false
+	}
+	return len(livingCombatants(opponents)) > 1 && ai.rng.Float64() < 0.35
+}
@@ -7,28 +7,48 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"MyGame/Struct/Bestiary"
 	"MyGame/Struct/Character"
+	"MyGame/Struct/Equipment"
+	"MyGame/Struct/Item"
+	"MyGame/combat"
+	"MyGame/config"
 	"MyGame/core"
+	"MyGame/events"
 	"MyGame/game/ui"
 	"MyGame/sound"
 )
 
 type FightModel struct {
-	gameManager     *core.ExtendedGameManager
-	turnHandler     *TurnHandler
-	itemManager     *ItemEffectManager
-	player          *Character.Character
-	enemy           *Character.Character
-	selected        int
-	state           FightViewState
-	Width           int
-	Height          int
-	message         string
-	showMessage     bool
-	round           int
-	waitingForEnemy bool
-	itemSelected    int
-	gameOver        bool
+	gameManager    *core.ExtendedGameManager
+	turnHandler    *TurnHandler
+	itemManager    *ItemEffectManager
+	bestiary       *Bestiary.Bestiary
+	encounter      *Bestiary.Encounter
+	player         *Character.Character
+	party          []*Combatant
+	enemies        []*Combatant
+	combatants     []*Combatant
+	scheduler      *combat.Scheduler
+	actor          *Combatant
+	selected       int
+	targetSelected int
+	state          FightViewState
+	Width          int
+	Height         int
+	message        string
+	showMessage    bool
+	round          int
+	itemSelected   int
+	gameOver       bool
+	seed           int64
+	limit          combat.RoundLimit
+	difficulty     config.DifficultyProfile
+	verdict        combat.Verdict
+	outcome        core.BattleOutcome
+	events         *events.Bus
+	pendingEvents  []string
+	mode           core.GameState
 }
 
 type FightViewState int
@@ -39,8 +59,19 @@ const (
 	FightViewSurrenderConfirm
 	FightViewExitConfirm
 	FightViewEnd
+	FightViewEncounter
+	FightViewTargetSelect
 )
 
+var fightActions = []string{
+	"⚔ Атака",
+	"🌀 Размашистый удар",
+	"🧪 Предмет",
+	"🏃 Бежать",
+	"📊 Статистика",
+	"🚪 Сдаться",
+}
+
 func NewFightModel(gameManager *core.ExtendedGameManager) *FightModel {
 	if gameManager == nil {
 		return nil
@@ -70,70 +101,478 @@ func NewFightModel(gameManager *core.ExtendedGameManager) *FightModel {
 		return nil
 	}
 
+	difficulty := gameManager.GetConfig().DifficultyProfile()
+	playerCopy.Level, playerCopy.XP = player.GetLevel(), player.XP
+	for _, slot := range player.Equipment.GetAllEquipmentSlots() {
+		if item := player.Equipment.GetItem(slot); item != nil {
+			_ = playerCopy.Equipment.Equip(item)
+		}
+	}
 	playerCopy.AddStarterItems()
+	setHealthPotions(playerCopy, difficulty.PlayerPotions)
+	if scroll := Item.CreateReturnScroll(); scroll != nil {
+		_ = playerCopy.Inventory.AddItem(scroll)
+	}
+	if salve := Item.CreateHealingSalve(); salve != nil {
+		_ = playerCopy.Inventory.AddItem(salve)
+	}
 	playerCopy.CalculateStats()
 
-	enemy, err := Character.New("Дракон", 120, 15, 1, 1)
-	if err != nil {
-		return nil
+	rng, seed := gameManager.Deps.NewBattleRNG()
+	playerCopy.SetRNG(rng)
+	bus := newBattleBus(gameManager.Events)
+	playerCopy.Events = bus
+
+	m := &FightModel{
+		gameManager:  gameManager,
+		turnHandler:  NewTurnHandler(rng),
+		itemManager:  NewItemEffectManager(),
+		bestiary:     gameManager.Bestiary,
+		player:       playerCopy,
+		selected:     0,
+		state:        FightViewEncounter,
+		Width:        ui.MinWidth,
+		Height:       ui.MinHeight,
+		message:      "",
+		showMessage:  false,
+		round:        1,
+		itemSelected: 0,
+		gameOver:     false,
+		seed:         seed,
+		events:       bus,
+		limit:        roundLimitFor(gameManager.GetConfig(), config.ModePvE),
+		difficulty:   difficulty,
 	}
-	enemy.AddStarterItems()
-	enemy.CalculateStats()
+	m.turnHandler.SetEvents(bus)
+	m.turnHandler.Pipeline().Use(combat.StageResist, m.limit.Modifier(func() int { return m.round }))
+	bus.Subscribe(events.Death, m.queueEvent("☠️ "))
+	bus.Subscribe(events.Failure, m.queueEvent("❌ "))
 
-	return &FightModel{
-		gameManager:     gameManager,
-		turnHandler:     NewTurnHandler(),
-		itemManager:     NewItemEffectManager(),
-		player:          playerCopy,
-		enemy:           enemy,
-		selected:        0,
-		state:           FightViewActionMenu,
-		Width:           ui.MinWidth,
-		Height:          ui.MinHeight,
-		message:         "",
-		showMessage:     false,
-		round:           1,
-		waitingForEnemy: false,
-		itemSelected:    0,
-		gameOver:        false,
+	if m.bestiary.Len() == 0 {
+		enemy, err := Character.New("Дракон", 120, 15, 1, 1)
+		if err != nil {
+			return nil
+		}
+		enemy.CalculateStats()
+		enemy.SetRNG(rng)
+		enemy.Events = bus
+		m.party = []*Combatant{{Char: m.player, Side: SideParty}}
+		m.enemies = []*Combatant{{Char: enemy, AI: NewEnemyAI(Bestiary.AIAggressive, m.turnHandler.rng), Side: SideEnemies}}
+		m.beginBattle("")
 	}
+
+	return m
 }
 
 func (m *FightModel) Init() tea.Cmd {
-	if m.player != nil && m.enemy != nil {
+	if m.player != nil {
 		m.player.CalculateStats()
-		m.enemy.CalculateStats()
 		m.round = 1
 		sound.PlayMusic()
 	}
 	return nil
 }
 
+func (m *FightModel) foe(id string) *Bestiary.Enemy {
+	return adjustedEnemy(m.bestiary.Get(id), m.difficulty)
+}
+
+func (m *FightModel) newCombatant(def *Bestiary.Enemy, side CombatSide) (*Combatant, error) {
+	char, err := def.NewCharacter()
+	if err != nil {
+		return nil, err
+	}
+	if side == SideEnemies {
+		setHealthPotions(char, m.difficulty.EnemyPotions)
+	}
+	char.SetRNG(m.turnHandler.rng)
+	char.Events = m.events
+	return &Combatant{Char: char, Def: def, AI: NewEnemyAI(def.AI, m.turnHandler.rng), Side: side}, nil
+}
+
+func (m *FightModel) StartScripted(id string) {
+	encounter := m.bestiary.Encounter(id)
+	if encounter == nil {
+		m.message = fmt.Sprintf("❌ Неизвестный бой: %s", id)
+		m.showMessage = true
+		return
+	}
+	m.mode = m.gameManager.GetState()
+	if m.mode == core.StateDungeon && m.gameManager.Dungeon != nil {
+		m.player.SetHP(m.gameManager.Dungeon.HP)
+	}
+	m.startEncounter(encounter, fmt.Sprintf("📜 %s преграждает путь!", encounter.Name))
+}
+
+func (m *FightModel) startEncounter(encounter *Bestiary.Encounter, intro string) {
+	if encounter == nil {
+		return
+	}
+
+	enemies := make([]*Combatant, 0, len(encounter.Enemies))
+	for _, id := range encounter.Enemies {
+		c, err := m.newCombatant(m.foe(id), SideEnemies)
+		if err != nil {
+			m.message = fmt.Sprintf("❌ Не удалось создать противника: %v", err)
+			m.showMessage = true
+			return
+		}
+		enemies = append(enemies, c)
+	}
+	party := []*Combatant{{Char: m.player, Side: SideParty}}
+	for _, id := range encounter.Allies {
+		c, err := m.newCombatant(m.bestiary.Get(id), SideParty)
+		if err != nil {
+			m.message = fmt.Sprintf("❌ Не удалось создать союзника: %v", err)
+			m.showMessage = true
+			return
+		}
+		party = append(party, c)
+	}
+	numberDuplicateNames(enemies)
+	numberDuplicateNames(party)
+
+	m.encounter = encounter
+	m.enemies = enemies
+	m.party = party
+	m.beginBattle(intro)
+}
+
+func numberDuplicateNames(list []*Combatant) {
+	counts := make(map[string]int)
+	for _, c := range list {
+		counts[c.Char.Name]++
+	}
+	seen := make(map[string]int)
+	for _, c := range list {
+		name := c.Char.Name
+		if counts[name] > 1 {
+			seen[name]++
+			c.Char.Name = fmt.Sprintf("%s #%d", name, seen[name])
+		}
+	}
+}
+
+func roundLimitFor(cfg *config.GameConfig, mode config.BattleMode) combat.RoundLimit {
+	rounds := config.MaxBattleRounds
+	if cfg != nil {
+		rounds = cfg.BattleRounds
+	}
+	return combat.NewRoundLimit(rounds, cfg.RoundRule(mode))
+}
+
+func (m *FightModel) beginBattle(intro string) {
+	m.round = 1
+	m.verdict = combat.VerdictNone
+	m.outcome = core.OutcomeNone
+	m.gameManager.StartBattle(m.enemyNameList())
+	m.selected = 0
+	m.state = FightViewActionMenu
+	m.buildScheduler()
+
+	lines := make([]string, 0)
+	if intro != "" {
+		lines = append(lines, intro)
+	}
+	m.runAITurns(&lines)
+	m.message = strings.Join(lines, "  │  ")
+	m.showMessage = m.message != ""
+	m.resolveBattleEnd()
+}
+
+func (m *FightModel) buildScheduler() {
+	m.combatants = append(append([]*Combatant{}, m.party...), m.enemies...)
+	m.scheduler = combat.NewScheduler()
+	m.actor = nil
+	for i, c := range m.combatants {
+		m.scheduler.Add(i, combat.Speed(c.Char.GetAgility(), c.Char.GetAttackSpeed()))
+	}
+}
+
+func (m *FightModel) syncScheduler() {
+	for i, c := range m.combatants {
+		if !c.IsAlive() {
+			m.scheduler.Remove(i)
+			continue
+		}
+		m.scheduler.SetSpeed(i, combat.Speed(combat.EffectiveAgility(c.Char), c.Char.GetAttackSpeed()))
+	}
+}
+
+func (m *FightModel) currentActor() *Combatant {
+	return m.actor
+}
+
+func (m *FightModel) runAITurns(lines *[]string) {
+	defer m.syncScheduler()
+	for !m.checkBattleEnd() {
+		m.syncScheduler()
+		id := m.scheduler.Next()
+		if id < 0 {
+			m.actor = nil
+			return
+		}
+		m.actor = m.combatants[id]
+		if round := m.scheduler.Round(); round != m.round {
+			m.round = round
+			if line := m.applyRoundLimit(); line != "" {
+				*lines = append(*lines, line)
+			}
+			if m.verdict != combat.VerdictNone {
+				m.actor = nil
+				return
+			}
+			if line := m.regenerateAll(); line != "" {
+				*lines = append(*lines, line)
+			}
+		}
+		if combat.EffectsOf(m.actor.Char).Stunned {
+			*lines = append(*lines, fmt.Sprintf("💫 %s: оглушение, ход пропущен", m.turnName(m.actor)))
+			m.endTurn(m.actor, lines)
+			continue
+		}
+		if m.actor.IsPlayerControlled() {
+			return
+		}
+		*lines = append(*lines, m.aiTurn(m.actor))
+		m.drainEvents(lines)
+		m.endTurn(m.actor, lines)
+	}
+}
+
+func (m *FightModel) endTurn(c *Combatant, lines *[]string) {
+	healed := c.Char.TickInjuries()
+	if len(healed) == 0 || !c.IsAlive() {
+		return
+	}
+	names := make([]string, 0, len(healed))
+	for _, injury := range healed {
+		names = append(names, injury.Name())
+	}
+	*lines = append(*lines, fmt.Sprintf("🩹 %s: прошло — %s", m.turnName(c), strings.Join(names, ", ")))
+}
+
+func newBattleBus(parent *events.Bus) *events.Bus {
+	bus := events.NewBus()
+	if parent != nil {
+		bus.SubscribeAll(parent.Emit)
+	}
+	return bus
+}
+
+func (m *FightModel) queueEvent(icon string) events.Handler {
+	return func(e events.Event) {
+		m.pendingEvents = append(m.pendingEvents, icon+e.Message)
+	}
+}
+
+func (m *FightModel) drainEvents(lines *[]string) {
+	*lines = append(*lines, m.pendingEvents...)
+	m.pendingEvents = m.pendingEvents[:0]
+}
+
+func (m *FightModel) applyRoundLimit() string {
+	if !m.limit.Exceeded(m.round) {
+		return ""
+	}
+	m.verdict = m.limit.Judge(m.round, combatantCharacters(m.party), combatantCharacters(m.enemies))
+	switch {
+	case m.verdict != combat.VerdictNone:
+		return fmt.Sprintf("⏱️ Лимит в %d раундов исчерпан (%s)", m.limit.Max, m.limit.RuleName())
+	case m.round == m.limit.Max+1:
+		return fmt.Sprintf("⚡ Лимит в %d раундов исчерпан: внезапная смерть, урон ×%.0f и растёт каждый раунд",
+			m.limit.Max, m.limit.DamageFactor(m.round))
+	}
+	return ""
+}
+
+func (m *FightModel) regenerateAll() string {
+	if m.limit.SuddenDeath(m.round) {
+		return ""
+	}
+	parts := make([]string, 0)
+	for _, c := range livingCombatants(m.combatants) {
+		if hp, _ := c.Char.Regenerate(); hp > 0 {
+			parts = append(parts, fmt.Sprintf("%s +%d", m.turnName(c), hp))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "✨ Регенерация: " + strings.Join(parts, ", ")
+}
+
+func (m *FightModel) upcomingTurns() string {
+	if m.scheduler == nil {
+		return ""
+	}
+	names := make([]string, 0, 7)
+	if m.actor != nil {
+		names = append(names, "▶ "+m.turnName(m.actor))
+	}
+	for _, id := range m.scheduler.Peek(6) {
+		names = append(names, m.turnName(m.combatants[id]))
+	}
+	return "⏳ Очередь: " + strings.Join(names, " → ")
+}
+
+func (m *FightModel) turnName(c *Combatant) string {
+	if c.Char == m.player {
+		return "Вы"
+	}
+	return c.Char.GetName()
+}
+
+func (m *FightModel) finishPlayerTurn(lines []string) {
+	m.drainEvents(&lines)
+	if m.actor != nil && m.actor.Char == m.player {
+		m.endTurn(m.actor, &lines)
+	}
+	m.runAITurns(&lines)
+	m.message = strings.Join(lines, "  │  ")
+	m.showMessage = true
+	m.resolveBattleEnd()
+}
+
+func (m *FightModel) opponentsOf(c *Combatant) []*Combatant {
+	if c.Side == SideParty {
+		return m.enemies
+	}
+	return m.party
+}
+
+func (m *FightModel) aiTurn(actor *Combatant) string {
+	if potion := actor.AI.ChooseHealingItem(actor.Char, m.itemManager); potion != nil {
+		if m.itemManager.UseItem(actor.Char, nil, potion) {
+			_, _ = actor.Char.GetInventory().RemoveItem(potion.Template.ID)
+			return fmt.Sprintf("🧪 %s использует %s! HP: %d/%d", actor.Char.GetName(), potion.Template.Name, actor.Char.GetHP(), actor.Char.GetMaxHP())
+		}
+	}
+
+	opponents := m.opponentsOf(actor)
+	if actor.AI.WantsAreaAttack(actor, opponents) {
+		return m.describeArea(actor.Char.GetName(), m.turnHandler.AreaStrike(actor.Char, combatantCharacters(livingCombatants(opponents))))
+	}
+
+	target := actor.AI.ChooseTarget(opponents)
+	if target == nil {
+		return fmt.Sprintf("%s не находит цели", actor.Char.GetName())
+	}
+	res := m.turnHandler.StrikeAt(actor.Char, target.Char, actor.AI.ChooseAttackPart(actor.Char))
+	m.logDamage(res)
+	return describeStrike(actor.Char, target.Char, res)
+}
+
+func describeStrike(attacker, target *Character.Character, res combat.DamageResult) string {
+	switch {
+	case res.SelfHit:
+		return fmt.Sprintf("😵 %s в замешательстве бьёт себя: %d урона (HP %d/%d)", attacker.GetName(), res.Damage, attacker.GetHP(), attacker.GetMaxHP()) + strikeExtras(res)
+	case res.Missed:
+		return fmt.Sprintf("🎯 %s промахивается по %s из-за травмы руки", attacker.GetName(), target.GetName())
+	case res.Blocked:
+		return fmt.Sprintf("🛡️ %s блокирует удар %s", target.GetName(), attacker.GetName())
+	case res.Dodged:
+		return fmt.Sprintf("💨 %s уклоняется от удара %s", target.GetName(), attacker.GetName())
+	}
+	line := fmt.Sprintf("⚔️ %s → %s: %d урона (HP %d/%d)", attacker.GetName(), target.GetName(), res.Damage, target.GetHP(), target.GetMaxHP())
+	return line + strikeExtras(res)
+}
+
+func strikeExtras(res combat.DamageResult) string {
+	extras := ""
+	if res.Critical {
+		extras += " 💢 крит!"
+	}
+	if res.Healed > 0 {
+		extras += fmt.Sprintf(" 🩸 +%d HP", res.Healed)
+	}
+	if res.Injury != nil {
+		extras += fmt.Sprintf(" 🩹 %s: %s", res.Defender.GetName(), res.Injury.Name())
+	}
+	if len(res.Broken) > 0 {
+		extras += fmt.Sprintf(" 🔧 сломано: %s", strings.Join(res.Broken, ", "))
+	}
+	return extras
+}
+
+func (m *FightModel) logDamage(results ...combat.DamageResult) {
+	logger := m.gameManager.Deps.GetLogger()
+	if logger == nil {
+		return
+	}
+	for _, res := range results {
+		logger.DamageEvent(m.round, res.String())
+	}
+}
+
+func (m *FightModel) describeArea(attackerName string, results []combat.DamageResult) string {
+	m.logDamage(results...)
+	parts := make([]string, 0, len(results))
+	for _, r := range results {
+		switch {
+		case r.SelfHit:
+			parts = append(parts, fmt.Sprintf("по себе −%d%s", r.Damage, strikeExtras(r)))
+		case r.Missed:
+			parts = append(parts, fmt.Sprintf("%s — промах", r.Defender.GetName()))
+		case r.Blocked:
+			parts = append(parts, fmt.Sprintf("%s — блок", r.Defender.GetName()))
+		case r.Dodged:
+			parts = append(parts, fmt.Sprintf("%s — уклонение", r.Defender.GetName()))
+		default:
+			parts = append(parts, fmt.Sprintf("%s −%d%s", r.Defender.GetName(), r.Damage, strikeExtras(r)))
+		}
+	}
+	return fmt.Sprintf("🌀 %s бьёт по площади: %s", attackerName, strings.Join(parts, ", "))
+}
+
+func (m *FightModel) updateEncounter(msg tea.KeyMsg) (*FightModel, tea.Cmd) {
+	encounters := m.bestiary.Encounters()
+	switch msg.String() {
+	case "up", "k":
+		if m.selected > 0 {
+			m.selected--
+		}
+	case "down", "j":
+		if m.selected < len(encounters) {
+			m.selected++
+		}
+	case "enter", " ":
+		if m.selected == 0 {
+			encounter := m.bestiary.RollEncounter(m.turnHandler.rng)
+			if encounter != nil {
+				m.startEncounter(encounter, fmt.Sprintf("🎲 Случайная встреча: %s!", encounter.Name))
+			}
+			return m, nil
+		}
+		encounter := encounters[m.selected-1]
+		m.startEncounter(encounter, fmt.Sprintf("⚔️ Вы вызываете на бой: %s!", encounter.Name))
+	case "esc":
+		return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+	}
+	return m, nil
+}
+
 func (m *FightModel) stopMusic() {
 	sound.StopMusic()
 }
 
 func (m *FightModel) Update(msg tea.Msg) (*FightModel, tea.Cmd) {
-	if m.checkBattleEnd() && !m.gameOver {
-		m.gameOver = true
-		m.state = FightViewEnd
-		if m.player.GetHP() <= 0 {
-			m.message = fmt.Sprintf("💀 Вы проиграли! %s победил!", m.enemy.GetName())
-		} else if m.enemy.GetHP() <= 0 {
-			m.message = fmt.Sprintf("🎉 Победа! Вы победили %s!", m.enemy.GetName())
-		}
-		m.showMessage = true
-	}
+	m.resolveBattleEnd()
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if m.gameOver && m.state == FightViewEnd {
-			return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+			outcome, origin, view := m.outcome, m.mode, postBattleView(m.gameManager, m.mode)
+			return m, func() tea.Msg { return ViewChangeMsg{View: view, Outcome: outcome, Origin: origin} }
 		}
 
 		switch m.state {
+		case FightViewEncounter:
+			return m.updateEncounter(msg)
 		case FightViewActionMenu:
 			return m.updateActionMenu(msg)
+		case FightViewTargetSelect:
+			return m.updateTargetSelect(msg)
 		case FightViewItemMenu:
 			return m.updateItemMenu(msg)
 		case FightViewSurrenderConfirm:
@@ -145,6 +584,125 @@ func (m *FightModel) Update(msg tea.Msg) (*FightModel, tea.Cmd) {
 	return m, nil
 }
 
+func (m *FightModel) resolveBattleEnd() {
+	if m.gameOver || !m.checkBattleEnd() {
+		return
+	}
+	switch m.verdict {
+	case combat.VerdictDraw:
+		m.finishBattle(core.OutcomeDraw, fmt.Sprintf("🤝 Ничья! За %d раундов победитель не определился", m.limit.Max))
+	case combat.VerdictSideA:
+		m.finishBattle(core.OutcomeVictory, fmt.Sprintf("🎉 Победа по очкам! Ваша группа сохранила больше здоровья, чем %s", m.enemyNames()))
+	case combat.VerdictSideB:
+		m.finishBattle(core.OutcomeDefeat, fmt.Sprintf("💀 Поражение по очкам! Больше здоровья сохранили: %s", m.enemyNames()))
+	default:
+		if !m.player.IsAlive() {
+			m.finishBattle(core.OutcomeDefeat, fmt.Sprintf("💀 Вы проиграли! Победу одержали: %s", m.enemyNames()))
+		} else {
+			m.finishBattle(core.OutcomeVictory, fmt.Sprintf("🎉 Победа! Вы одолели: %s", m.enemyNames()))
+		}
+	}
+}
+
+func (m *FightModel) finishBattle(outcome core.BattleOutcome, message string) {
+	m.gameOver = true
+	m.state = FightViewEnd
+	m.outcome = outcome
+	m.message = message
+	m.showMessage = true
+	result := core.BattleResult{
+		Outcome:    outcome,
+		Enemies:    m.enemyNameList(),
+		EnemyIDs:   m.enemyIDList(),
+		Rounds:     m.round,
+		Difficulty: string(m.difficulty.ID),
+		Seed:       m.seed,
+	}
+	if outcome == core.OutcomeVictory {
+		m.collectRewards(&result)
+	}
+	m.gameManager.EndBattle(result)
+	switch m.mode {
+	case core.StateStory:
+		if outcome != core.OutcomeVictory {
+			break
+		}
+		if _, err := m.gameManager.CompleteScene(); err != nil {
+			m.message += fmt.Sprintf("\n❌ %v", err)
+		}
+	case core.StateDungeon:
+		m.gameManager.FinishDungeonBattle(outcome, m.player.GetHP())
+	}
+	m.gameManager.AutoSave()
+}
+
+func (m *FightModel) collectRewards(result *core.BattleResult) {
+	result.XP = m.victoryXP()
+	m.message += fmt.Sprintf("\n✨ Получено опыта: %d", result.XP)
+	for _, up := range m.gameManager.AwardXP(result.XP) {
+		m.message += fmt.Sprintf("\n⬆️ Новый уровень: %d!", up.Level)
+	}
+
+	items := make([]*Item.Item, 0)
+	for _, c := range m.enemies {
+		if c.Def == nil {
+			continue
+		}
+		result.Gold += c.Def.RollGold(m.turnHandler.rng)
+		item, err := c.Def.RollLoot(m.turnHandler.rng, m.difficulty.LootChance, m.difficulty.LootRarityBonus)
+		if err != nil {
+			m.message += fmt.Sprintf("\n❌ %v", err)
+			continue
+		}
+		if item != nil {
+			items = append(items, item)
+			result.Loot = append(result.Loot, item.GetFullName())
+		}
+	}
+	m.gameManager.AwardGold(result.Gold)
+	m.gameManager.AddLoot(items)
+	if result.Gold > 0 {
+		m.message += fmt.Sprintf("\n💰 Золото: +%d", result.Gold)
+	}
+	if len(items) > 0 {
+		m.message += fmt.Sprintf("\n🎁 Добыча: %s", strings.Join(result.Loot, ", "))
+	}
+}
+
+func (m *FightModel) victoryXP() int {
+	xp := 0
+	for _, c := range m.enemies {
+		def := c.Def
+		if def == nil {
+			def = &Bestiary.Enemy{Level: c.Char.GetLevel(), HP: c.Char.GetMaxHP(), Strength: c.Char.GetStrength(), Agility: c.Char.GetAgility()}
+		}
+		xp += def.XPReward()
+	}
+	return xp
+}
+
+func (m *FightModel) enemyNameList() []string {
+	names := make([]string, 0, len(m.enemies))
+	for _, c := range m.enemies {
+		names = append(names, c.Char.GetName())
+	}
+	return names
+}
+
+func (m *FightModel) enemyIDList() []string {
+	ids := make([]string, 0, len(m.enemies))
+	for _, c := range m.enemies {
+		if c.Def != nil {
+			ids = append(ids, c.Def.ID)
+		}
+	}
+	return ids
+}
+
+func (m *FightModel) enemyNames() string {
+	return strings.Join(m.enemyNameList(), ", ")
+}
+
 func (m *FightModel) updateActionMenu(msg tea.KeyMsg) (*FightModel, tea.Cmd) {
 	switch msg.String() {
 	case "up", "k":
@@ -152,22 +710,33 @@ func (m *FightModel) updateActionMenu(msg tea.KeyMsg) (*FightModel, tea.Cmd) {
 			m.selected--
 		}
 	case "down", "j":
-		if m.selected < 3 {
+		if m.selected < len(fightActions)-1 {
 			m.selected++
 		}
 	case "enter", " ":
 		switch m.selected {
 		case 0:
-			m.doPlayerAttackAndEnemyTurn()
-			return m, nil
+			living := livingCombatants(m.enemies)
+			if len(living) == 1 {
+				m.doPlayerAttack(living[0])
+				return m, nil
+			}
+			m.state = FightViewTargetSelect
+			m.targetSelected = 0
 		case 1:
+			m.doPlayerAreaAttack()
+			return m, nil
+		case 2:
 			m.state = FightViewItemMenu
 			m.itemSelected = 0
-		case 2:
+		case 3:
+			m.doPlayerFlee()
+			return m, nil
+		case 4:
 			m.showMessage = true
 			m.message = m.getBattleStats()
 			return m, nil
-		case 3:
+		case 5:
 			m.state = FightViewSurrenderConfirm
 			m.selected = 0
 		}
@@ -178,10 +747,41 @@ func (m *FightModel) updateActionMenu(msg tea.KeyMsg) (*FightModel, tea.Cmd) {
 	return m, nil
 }
 
+func (m *FightModel) updateTargetSelect(msg tea.KeyMsg) (*FightModel, tea.Cmd) {
+	living := livingCombatants(m.enemies)
+	if len(living) == 0 {
+		m.state = FightViewActionMenu
+		return m, nil
+	}
+	if m.targetSelected >= len(living) {
+		m.targetSelected = len(living) - 1
+	}
+	switch msg.String() {
+	case "left", "up", "h", "k":
+		if m.targetSelected > 0 {
+			m.targetSelected--
+		}
+	case "right", "down", "l", "j":
+		if m.targetSelected < len(living)-1 {
+			m.targetSelected++
+		}
+	case "enter", " ":
+		m.state = FightViewActionMenu
+		m.doPlayerAttack(living[m.targetSelected])
+	case "esc":
+		m.state = FightViewActionMenu
+	}
+	return m, nil
+}
+
 func (m *FightModel) updateExitConfirm(msg tea.KeyMsg) (*FightModel, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y", "д", "Д":
-		return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+		if m.mode != core.StateMenu && !m.gameOver && len(m.enemies) > 0 {
+			m.finishBattle(core.OutcomeSurrender, "🏳️ Вы покинули бой и отступили")
+		}
+		outcome := m.outcome
+		return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu, Outcome: outcome} }
 	case "n", "N", "н", "Н", "esc":
 		m.state = FightViewActionMenu
 		m.selected = 0
@@ -189,33 +789,63 @@ func (m *FightModel) updateExitConfirm(msg tea.KeyMsg) (*FightModel, tea.Cmd) {
 	return m, nil
 }
 
-func (m *FightModel) doPlayerAttackAndEnemyTurn() {
+func (m *FightModel) doPlayerAttack(target *Combatant) {
 	if m.turnHandler == nil {
 		m.message = "Ошибка боевой системы"
 		m.showMessage = true
 		return
 	}
-	var lines []string
-	damage1, blocked1 := m.turnHandler.SimpleStrike(m.player, m.enemy)
-	if blocked1 {
-		lines = append(lines, fmt.Sprintf("🛡️ %s заблокировал удар!", m.enemy.GetName()))
-	} else {
-		lines = append(lines, fmt.Sprintf("💥 Вы нанесли %d урона! %s: %d/%d HP", damage1, m.enemy.GetName(), m.enemy.GetHP(), m.enemy.GetMaxHP()))
+	res := m.turnHandler.SimpleStrike(m.player, target.Char)
+	m.logDamage(res)
+	var line string
+	switch {
+	case res.SelfHit:
+		line = fmt.Sprintf("😵 В замешательстве вы бьёте себя: %d урона!", res.Damage) + strikeExtras(res)
+	case res.Missed:
+		line = fmt.Sprintf("🎯 Травма руки: вы промахнулись по %s!", target.Char.GetName())
+	case res.Blocked:
+		line = fmt.Sprintf("🛡️ %s заблокировал удар!", target.Char.GetName())
+	case res.Dodged:
+		line = fmt.Sprintf("💨 %s увернулся!", target.Char.GetName())
+	default:
+		line = fmt.Sprintf("💥 Вы нанесли %d урона! %s: %d/%d HP", res.Damage, target.Char.GetName(), target.Char.GetHP(), target.Char.GetMaxHP()) + strikeExtras(res)
 	}
-	if m.checkBattleEnd() {
-		m.message = lines[0]
-		m.showMessage = true
+	m.finishPlayerTurn([]string{line})
+}
+
+func (m *FightModel) doPlayerFlee() {
+	living := livingCombatants(m.enemies)
+	agilities := make([]int, 0, len(living))
+	var pursuer *Combatant
+	for _, c := range living {
+		agilities = append(agilities, combat.EffectiveAgility(c.Char))
+		if pursuer == nil || combat.EffectiveAgility(c.Char) > combat.EffectiveAgility(pursuer.Char) {
+			pursuer = c
+		}
+	}
+	chance := combat.FleeChance(combat.EffectiveAgility(m.player), agilities)
+	if m.turnHandler.rng.Float64() < chance {
+		m.finishBattle(core.OutcomeFled, fmt.Sprintf("🏃 Вы сбежали от: %s (шанс %.0f%%)", m.enemyNames(), chance*100))
 		return
 	}
-	damage2, blocked2 := m.turnHandler.SimpleStrike(m.enemy, m.player)
-	m.round++
-	if blocked2 {
-		lines = append(lines, fmt.Sprintf("🛡️ Вы заблокировали атаку %s!", m.enemy.GetName()))
-	} else {
-		lines = append(lines, fmt.Sprintf("⚔️ %s наносит %d урона! Ваш HP: %d/%d", m.enemy.GetName(), damage2, m.player.GetHP(), m.player.GetMaxHP()))
+
+	lines := []string{fmt.Sprintf("🏃 Побег не удался (шанс %.0f%%)!", chance*100)}
+	if pursuer != nil {
+		res := m.turnHandler.ApplyHit(pursuer.Char, m.player, pursuer.Char.Hit(), 1.0)
+		m.logDamage(res)
+		lines = append(lines, "Удар вдогонку: "+describeStrike(pursuer.Char, m.player, res))
 	}
-	m.message = strings.Join(lines, "  │  ")
-	m.showMessage = true
+	m.finishPlayerTurn(lines)
+}
+
+func (m *FightModel) doPlayerAreaAttack() {
+	if m.turnHandler == nil {
+		m.message = "Ошибка боевой системы"
+		m.showMessage = true
+		return
+	}
+	results := m.turnHandler.AreaStrike(m.player, combatantCharacters(livingCombatants(m.enemies)))
+	m.finishPlayerTurn([]string{m.describeArea("Вы", results)})
 }
 
 func (m *FightModel) updateItemMenu(msg tea.KeyMsg) (*FightModel, tea.Cmd) {
@@ -248,36 +878,22 @@ func (m *FightModel) updateItemMenu(msg tea.KeyMsg) (*FightModel, tea.Cmd) {
 	case "enter", " ":
 
 		selectedItem := usableItems[m.itemSelected]
-		success := m.itemManager.UseItem(m.player, m.enemy, selectedItem)
+		success := m.itemManager.UseItem(m.player, nil, selectedItem)
 
 		if success {
+			var line string
 			if _, err := m.player.GetInventory().RemoveItem(selectedItem.Template.ID); err != nil {
-				m.message = fmt.Sprintf("✅ %s использован (предмет не удалён из инвентаря: %v)", selectedItem.Template.Name, err)
+				line = fmt.Sprintf("✅ %s использован (предмет не удалён из инвентаря: %v)", selectedItem.Template.Name, err)
 			} else {
-				m.message = fmt.Sprintf("✅ %s использован!", selectedItem.Template.Name)
+				line = fmt.Sprintf("✅ %s использован!", selectedItem.Template.Name)
 			}
-			m.showMessage = true
 			m.state = FightViewActionMenu
 			m.itemSelected = 0
-
-			if m.checkBattleEnd() {
-				m.gameOver = true
-				m.state = FightViewEnd
-				if m.player.GetHP() <= 0 {
-					m.message += fmt.Sprintf("  │  💀 Вы проиграли! %s победил!", m.enemy.GetName())
-				} else if m.enemy.GetHP() <= 0 {
-					m.message += fmt.Sprintf("  │  🎉 Победа! Вы победили %s!", m.enemy.GetName())
-				}
+			if m.itemManager.IsEscapeItem(selectedItem) {
+				m.finishBattle(core.OutcomeFled, fmt.Sprintf("📜 %s переносит вас в безопасное место", selectedItem.Template.Name))
 				return m, nil
 			}
-
-			damage, blocked := m.turnHandler.SimpleStrike(m.enemy, m.player)
-			m.round++
-			if blocked {
-				m.message += fmt.Sprintf("  │  🛡️ Вы заблокировали атаку %s!", m.enemy.GetName())
-			} else {
-				m.message += fmt.Sprintf("  │  ⚔️ %s наносит %d урона! Ваш HP: %d/%d", m.enemy.GetName(), damage, m.player.GetHP(), m.player.GetMaxHP())
-			}
+			m.finishPlayerTurn([]string{line})
 			return m, nil
 		}
 		m.message = fmt.Sprintf("❌ Не удалось использовать %s", selectedItem.Template.Name)
@@ -302,10 +918,7 @@ func (m *FightModel) updateSurrenderConfirm(msg tea.KeyMsg) (*FightModel, tea.Cm
 		}
 	case "enter", " ":
 		if m.selected == 0 {
-			m.gameOver = true
-			m.state = FightViewEnd
-			m.message = "Вы сдались!"
-			m.showMessage = true
+			m.finishBattle(core.OutcomeSurrender, "Вы сдались!")
 		} else {
 			m.state = FightViewActionMenu
 			m.selected = 0
@@ -318,30 +931,160 @@ func (m *FightModel) updateSurrenderConfirm(msg tea.KeyMsg) (*FightModel, tea.Cm
 }
 
 func (m *FightModel) checkBattleEnd() bool {
-	if m.player == nil || m.enemy == nil {
+	if m.player == nil || len(m.enemies) == 0 {
 		return false
 	}
-	return m.player.GetHP() <= 0 || m.enemy.GetHP() <= 0
+	return m.verdict != combat.VerdictNone || !m.player.IsAlive() || len(livingCombatants(m.enemies)) == 0
 }
 
 func (m *FightModel) getBattleStats() string {
-	return fmt.Sprintf(`📊 СТАТИСТИКА БОЯ
-
-%s: HP=%d/%d, Атака=%.1f, Защита=%.1f
-%s: HP=%d/%d, Атака=%.1f, Защита=%.1f`,
-		m.player.GetName(), m.player.GetHP(), m.player.GetMaxHP(),
-		m.player.GetAttack(), m.player.GetDefense(),
-		m.enemy.GetName(), m.enemy.GetHP(), m.enemy.GetMaxHP(),
-		m.enemy.GetAttack(), m.enemy.GetDefense())
+	lines := []string{fmt.Sprintf("📊 СТАТИСТИКА БОЯ (сид %d, сложность: %s)", m.seed, m.difficulty.Name)}
+	for _, c := range append(append([]*Combatant{}, m.party...), m.enemies...) {
+		lines = append(lines, fmt.Sprintf("%s: HP=%d/%d, Атака=%.1f, Защита=%.1f, Инициатива=%.0f",
+			c.Char.GetName(), c.Char.GetHP(), c.Char.GetMaxHP(), c.Char.GetAttack(), c.Char.GetDefense(),
+			combat.Speed(c.Char.GetAgility(), c.Char.GetAttackSpeed())))
+	}
+	p := m.player
+	lines = append(lines, fmt.Sprintf("Вы: Крит=%.0f%% ×%.1f, Уклонение=%.0f%%, Вампиризм=%.0f%%, Усиление магии=%.0f%%, Регенерация=%.1f HP/%.1f MP",
+		p.GetCritChance()*100, p.GetCritDamage(), p.GetEvasion()*100, p.GetLifesteal()*100, p.GetMagicAmp()*100, p.HealthRegen, p.ManaRegen))
+	armor := make([]string, 0, len(Equipment.ArmorZones))
+	for _, zone := range Equipment.ArmorZones {
+		armor = append(armor, fmt.Sprintf("%s %.1f", zone.Name, p.GetZoneDefense(zone.Parts[0])))
+	}
+	lines = append(lines, "Броня по зонам: "+strings.Join(armor, ", "))
+	return strings.Join(lines, "\n")
 }
 
 func (m *FightModel) View() string {
-	if m.state == FightViewEnd {
+	switch m.state {
+	case FightViewEnd:
 		return m.renderEndScreen()
+	case FightViewEncounter:
+		return m.renderEncounterScreen()
 	}
 	return m.renderBattleScreen()
 }
 
+func (m *FightModel) renderEncounterScreen() string {
+	var b strings.Builder
+	width := m.Width
+	if width < ui.MinWidth {
+		width = ui.MinWidth
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ui.ColorTitle)).
+		Bold(true).
+		Padding(0, 1)
+	b.WriteString(ui.CenteredLine(titleStyle.Render("🐉 ВЫБОР ПРОТИВНИКА  │  Сложность: "+m.difficulty.Name), width))
+	b.WriteString("\n\n")
+
+	encounters := m.bestiary.Encounters()
+	items := []string{"🎲 Случайная встреча"}
+	for _, e := range encounters {
+		if len(e.Enemies) > 1 {
+			items = append(items, fmt.Sprintf("👥 %s (%d противн.)", e.Name, len(e.Enemies)))
+		} else if def := m.foe(e.Enemies[0]); def != nil {
+			items = append(items, fmt.Sprintf("%s (ур. %d)", def.Name, def.Level))
+		}
+	}
+	for i, item := range items {
+		b.WriteString(ui.CenteredLine(ui.RenderMenuItem(i == m.selected, item), width) + "\n")
+	}
+	b.WriteString("\n")
+
+	if m.selected > 0 && m.selected <= len(encounters) {
+		b.WriteString(m.renderEncounterPreview(encounters[m.selected-1], width))
+	} else {
+		b.WriteString(ui.CenteredLine(ui.WarningStyle.Render("Противник будет выбран случайно — кто знает, кто выйдет из тумана?"), width))
+		b.WriteString("\n")
+	}
+
+	if m.showMessage && m.message != "" {
+		b.WriteString("\n" + ui.CenteredLine(ui.DangerStyle.Render(m.message), width) + "\n")
+	}
+
+	b.WriteString("\n" + ui.CenteredLine(ui.HelpStyle.Render("↑↓ Выбор  │  Enter В бой  │  ESC Назад"), width))
+	return b.String()
+}
+
+func (m *FightModel) renderEncounterPreview(encounter *Bestiary.Encounter, width int) string {
+	if len(encounter.Enemies) == 1 {
+		return renderEnemyPreview(m.foe(encounter.Enemies[0]), width)
+	}
+
+	var b strings.Builder
+	b.WriteString(renderEnemyPreview(m.foe(encounter.Enemies[0]), width))
+	b.WriteString("\n")
+	statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorStats))
+	b.WriteString(ui.CenteredLine(statsStyle.Render("Противники: "+m.bestiaryNames(encounter.Enemies)), width) + "\n")
+	if len(encounter.Allies) > 0 {
+		allyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorSuccess))
+		b.WriteString(ui.CenteredLine(allyStyle.Render("Союзники: "+m.bestiaryNames(encounter.Allies)), width) + "\n")
+	}
+	return b.String()
+}
+
+func (m *FightModel) bestiaryNames(ids []string) string {
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if def := m.bestiary.Get(id); def != nil {
+			names = append(names, def.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func renderEnemyPreview(def *Bestiary.Enemy, width int) string {
+	if def == nil {
+		return ""
+	}
+	var b strings.Builder
+	artStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorDanger))
+	artWidth := 0
+	for _, line := range def.Art {
+		artWidth = max(artWidth, len([]rune(line)))
+	}
+	for _, line := range def.Art {
+		ui.CenteredLineByVisibleWidth(&b, artStyle.Render(line), artWidth, width)
+	}
+	b.WriteString("\n")
+
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorDanger)).Bold(true)
+	b.WriteString(ui.CenteredLine(nameStyle.Render(fmt.Sprintf("◆ %s — уровень %d", def.Name, def.Level)), width) + "\n")
+	statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorStats))
+	stats := fmt.Sprintf("❤️  %d  │  💪 %d  │  🏃 %d  │  🧠 %d  │  Поведение: %s",
+		def.HP, def.Strength, def.Agility, def.Intelligence, def.AI.DisplayName())
+	b.WriteString(ui.CenteredLine(statsStyle.Render(stats), width) + "\n")
+	if def.Description != "" {
+		b.WriteString(ui.CenteredLine(ui.NormalStyle.Render(def.Description), width) + "\n")
+	}
+	return b.String()
+}
+
+func (m *FightModel) renderSide(list []*Combatant, width int) string {
+	panelWidth := min(44, max(ui.MinCharacterBoxWidth, (width-2)/max(len(list), 1)))
+	living := livingCombatants(m.enemies)
+	panels := make([]string, 0, len(list))
+	for _, c := range list {
+		label := "Враг"
+		switch {
+		case c.Char == m.player:
+			label = "Вы"
+		case c.Side == SideParty:
+			label = "Союзник"
+		}
+		if m.state == FightViewTargetSelect && m.targetSelected < len(living) && living[m.targetSelected] == c {
+			label = "🎯 Цель"
+		}
+		if actor := m.currentActor(); actor == c && m.state != FightViewTargetSelect {
+			label = "▶ " + label
+		}
+		panels = append(panels, ui.RenderCharacterBox(c.Char, label, panelWidth))
+	}
+	return ui.RenderPanelsRow(panels, width)
+}
+
 func (m *FightModel) renderBattleScreen() string {
 	var b strings.Builder
 
@@ -354,21 +1097,21 @@ func (m *FightModel) renderBattleScreen() string {
 		Foreground(lipgloss.Color(ui.ColorTitle)).
 		Bold(true).
 		Padding(0, 1)
-	title := roundStyle.Render(fmt.Sprintf("⚔️ РАУНД %d", m.round))
+	title := roundStyle.Render("⚔️ " + m.limit.Label(m.round))
 	b.WriteString(ui.CenteredLine(title, width))
+	b.WriteString("\n")
+	queueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorHelp))
+	b.WriteString(ui.CenteredLine(queueStyle.Render(m.upcomingTurns()), width))
 	b.WriteString("\n\n")
 
-	enemyName := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorDanger)).Bold(true).Render("◆ " + m.enemy.GetName())
-	b.WriteString(ui.RenderBattleHpLine(enemyName, m.enemy.GetHP(), m.enemy.GetMaxHP(), width))
-	b.WriteString("\n")
+	b.WriteString(m.renderSide(m.enemies, width))
 
 	vsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorBorder)).Bold(true)
 	b.WriteString(ui.CenteredLine(vsStyle.Render("────── VS ──────"), width))
 	b.WriteString("\n")
 
-	playerName := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorSuccess)).Bold(true).Render("◆ " + m.player.GetName())
-	b.WriteString(ui.RenderBattleHpLine(playerName, m.player.GetHP(), m.player.GetMaxHP(), width))
-	b.WriteString("\n\n")
+	b.WriteString(m.renderSide(m.party, width))
+	b.WriteString("\n")
 
 	if m.showMessage && m.message != "" {
 		logLine := strings.ReplaceAll(m.message, "\n", " ")
@@ -385,6 +1128,8 @@ func (m *FightModel) renderBattleScreen() string {
 	switch m.state {
 	case FightViewActionMenu:
 		b.WriteString(m.renderActionMenu())
+	case FightViewTargetSelect:
+		b.WriteString(ui.WarningStyle.Render("🎯 Выберите цель: ←→ сменить, Enter — атаковать, ESC — отмена") + "\n")
 	case FightViewItemMenu:
 		b.WriteString(m.renderItemMenu())
 	case FightViewSurrenderConfirm:
@@ -404,20 +1149,20 @@ func (m *FightModel) renderBattleScreen() string {
 }
 
 func (m *FightModel) renderActionMenu() string {
-	items := []string{
-		"⚔ Атака",
-		"🧪 Предмет",
-		"📊 Статистика",
-		"🚪 Сдаться",
-	}
 	var b strings.Builder
-	for i, item := range items {
+	for i, item := range fightActions {
 		b.WriteString(ui.RenderMenuItem(i == m.selected, item) + "\n")
 	}
 	return b.String()
 }
 
 func (m *FightModel) renderExitConfirm() string {
+	switch m.mode {
+	case core.StateDungeon:
+		return ui.WarningStyle.Render("🚪 Выйти из боя? Вы отступите из комнаты. Y — да  N/ESC — нет") + "\n"
+	case core.StateStory:
+		return ui.WarningStyle.Render("🚪 Выйти из боя? Сцену придётся начать заново. Y — да  N/ESC — нет") + "\n"
+	}
 	return ui.WarningStyle.Render("🚪 Выйти из боя? Y — да  N/ESC — нет") + "\n"
 }
 
@@ -478,11 +1223,24 @@ func (m *FightModel) renderEndScreen() string {
 	title := titleStyle.Render("🎉 БОЙ ЗАВЕРШЕН 🎉")
 	b.WriteString(ui.CenteredLine(title, width) + "\n\n")
 
-	if m.message != "" {
-		b.WriteString(ui.CenteredLine(m.message, width) + "\n\n")
+	for _, line := range strings.Split(m.message, "\n") {
+		if line != "" {
+			b.WriteString(ui.CenteredLine(line, width) + "\n")
+		}
 	}
+	b.WriteString("\n")
 
 	help := ui.HelpStyle.Render("▶ Любая клавиша — в главное меню")
+	switch postBattleView(m.gameManager, m.mode) {
+	case ViewLoot:
+		help = ui.HelpStyle.Render("▶ Любая клавиша — к добыче")
+	case ViewLevelUp:
+		help = ui.HelpStyle.Render("▶ Любая клавиша — к новому уровню")
+	case ViewCampaign:
+		help = ui.HelpStyle.Render("▶ Любая клавиша — к кампании")
+	case ViewDungeon:
+		help = ui.HelpStyle.Render("▶ Любая клавиша — к карте подземелья")
+	}
 	b.WriteString(ui.CenteredLine(help, width))
 
 	return b.String()
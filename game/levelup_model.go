@@ -0,0 +1,78 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"MyGame/Struct/Character"
+	"MyGame/core"
+	"MyGame/game/ui"
+)
+
+type LevelUpModel struct {
+	gameManager *core.ExtendedGameManager
+	levelUps    []Character.LevelUp
+	origin      core.GameState
+	Width       int
+	Height      int
+}
+
+func NewLevelUpModel(gameManager *core.ExtendedGameManager) *LevelUpModel {
+	m := &LevelUpModel{
+		gameManager: gameManager,
+		Width:       ui.MinWidth,
+		Height:      ui.MinHeight,
+	}
+	if gameManager != nil {
+		m.levelUps = gameManager.TakeLevelUps()
+	}
+	return m
+}
+
+func (m *LevelUpModel) Update(msg tea.Msg) (*LevelUpModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if keyMsg.String() == "enter" || keyMsg.String() == " " {
+		return m, func() tea.Msg { return ViewChangeMsg{View: ViewCharacter} }
+	}
+	view := postBattleView(m.gameManager, m.origin)
+	return m, func() tea.Msg { return ViewChangeMsg{View: view} }
+}
+
+func (m *LevelUpModel) View() string {
+	var b strings.Builder
+	width := max(m.Width, ui.MinWidth)
+
+	for i := 0; i < max(0, (m.Height-20)/3); i++ {
+		b.WriteString("\n")
+	}
+
+	titleStyle := ui.TitleStyle.Copy().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(ui.ColorBorder)).Padding(0, 1)
+	ui.CenteredLineBuilder(&b, titleStyle.Render("⬆️ НОВЫЙ УРОВЕНЬ!"), width)
+	b.WriteString("\n")
+
+	gainStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorSuccess)).Bold(true)
+	for _, up := range m.levelUps {
+		ui.CenteredLineBuilder(&b, gainStyle.Render(fmt.Sprintf("Уровень %d:  ❤️ +%d  │  ⭐ +%d очк. характеристик",
+			up.Level, up.HP, up.Points)), width)
+	}
+	b.WriteString("\n")
+
+	if player := m.gameManager.GetPlayer(); player != nil {
+		statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorStats))
+		ui.CenteredLineBuilder(&b, statsStyle.Render(fmt.Sprintf("%s — уровень %d, опыт %d/%d",
+			player.GetName(), player.GetLevel(), player.XP, m.gameManager.GetConfig().XPForLevel(player.GetLevel()))), width)
+		ui.CenteredLineBuilder(&b, statsStyle.Render(fmt.Sprintf("❤️  %d  │  💪 %d  │  🏃 %d  │  🧠 %d",
+			player.GetBaseHP(), player.GetBaseStrength(), player.GetBaseAgility(), player.GetBaseIntelligence())), width)
+		ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(fmt.Sprintf("Здоровье полностью восстановлено, свободных очков: %d", player.StatPoints)), width)
+	}
+
+	b.WriteString("\n")
+	ui.CenteredLineBuilder(&b, ui.HelpStyle.Render("Enter — распределить очки  │  Любая другая клавиша — в главное меню"), width)
+	return b.String()
+}
@@ -0,0 +1,213 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"MyGame/Struct/Item"
+	"MyGame/core"
+	"MyGame/game/ui"
+)
+
+type lootMode int
+
+const (
+	lootModeList lootMode = iota
+	lootModeReplace
+	lootModeLeave
+)
+
+type LootModel struct {
+	gameManager *core.ExtendedGameManager
+	items       []*Item.Item
+	selected    int
+	replace     int
+	mode        lootMode
+	origin      core.GameState
+	message     string
+	Width       int
+	Height      int
+}
+
+func postBattleView(gm *core.ExtendedGameManager, origin core.GameState) ViewType {
+	switch {
+	case gm.HasLoot():
+		return ViewLoot
+	case gm.HasLevelUps():
+		return ViewLevelUp
+	case origin == core.StateStory:
+		return ViewCampaign
+	case origin == core.StateDungeon:
+		return ViewDungeon
+	}
+	return ViewMainMenu
+}
+
+func NewLootModel(gameManager *core.ExtendedGameManager) *LootModel {
+	return &LootModel{
+		gameManager: gameManager,
+		items:       gameManager.TakeLoot(),
+		Width:       ui.MinWidth,
+		Height:      ui.MinHeight,
+	}
+}
+
+func (m *LootModel) leave() tea.Cmd {
+	m.gameManager.AutoSave()
+	view, origin := postBattleView(m.gameManager, m.origin), m.origin
+	return func() tea.Msg { return ViewChangeMsg{View: view, Origin: origin} }
+}
+
+func (m *LootModel) Update(msg tea.Msg) (*LootModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	player := m.gameManager.GetPlayer()
+	if player == nil || len(m.items) == 0 {
+		return m, m.leave()
+	}
+
+	switch m.mode {
+	case lootModeReplace:
+		inventory := player.GetInventory().GetItems()
+		switch keyMsg.String() {
+		case "up", "k":
+			if m.replace > 0 {
+				m.replace--
+			}
+		case "down", "j":
+			if m.replace < len(inventory)-1 {
+				m.replace++
+			}
+		case "enter", " ":
+			dropped, err := player.GetInventory().RemoveAt(m.replace)
+			if err != nil {
+				m.message = fmt.Sprintf("❌ %v", err)
+				return m, nil
+			}
+			m.take()
+			m.message = fmt.Sprintf("♻️ Выброшено: %s", dropped.GetFullName()) + "  │  " + m.message
+			m.mode = lootModeList
+		case "n", "N", "н", "Н", "backspace", "esc":
+			m.mode = lootModeList
+		}
+		return m, nil
+	case lootModeLeave:
+		switch keyMsg.String() {
+		case "y", "Y", "д", "Д", "enter":
+			return m, m.leave()
+		case "n", "N", "н", "Н", "esc":
+			m.mode = lootModeList
+		}
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.selected > 0 {
+			m.selected--
+		}
+	case "down", "j":
+		if m.selected < len(m.items)-1 {
+			m.selected++
+		}
+	case "enter", " ":
+		if player.GetInventory().IsFull() {
+			m.mode = lootModeReplace
+			m.replace = 0
+			m.message = "🎒 Инвентарь полон: выберите предмет, который выбросить"
+			return m, nil
+		}
+		m.take()
+	case "a", "ф":
+		for len(m.items) > 0 && !player.GetInventory().IsFull() {
+			m.selected = 0
+			m.take()
+		}
+		if len(m.items) > 0 {
+			m.message = fmt.Sprintf("🎒 Инвентарь полон, осталось предметов: %d", len(m.items))
+		}
+	case "q", "й", "esc":
+		m.mode = lootModeLeave
+		return m, nil
+	}
+	if len(m.items) == 0 {
+		return m, m.leave()
+	}
+	return m, nil
+}
+
+func (m *LootModel) take() {
+	item := m.items[m.selected]
+	if err := m.gameManager.GiveItem(item); err != nil {
+		m.message = fmt.Sprintf("❌ %v", err)
+		return
+	}
+	m.items = append(m.items[:m.selected], m.items[m.selected+1:]...)
+	m.selected = min(m.selected, max(len(m.items)-1, 0))
+	m.message = fmt.Sprintf("✅ Взято: %s", item.GetFullName())
+}
+
+func (m *LootModel) View() string {
+	var b strings.Builder
+	width := max(m.Width, ui.MinWidth)
+
+	for i := 0; i < max(0, (m.Height-30)/3); i++ {
+		b.WriteString("\n")
+	}
+
+	titleStyle := ui.TitleStyle.Copy().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(ui.ColorBorder)).Padding(0, 1)
+	ui.CenteredLineBuilder(&b, titleStyle.Render("🎁 ДОБЫЧА"), width)
+	b.WriteString("\n")
+
+	player := m.gameManager.GetPlayer()
+	if player != nil {
+		inv := player.GetInventory()
+		statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorStats))
+		ui.CenteredLineBuilder(&b, statsStyle.Render(fmt.Sprintf("💰 Золото: %d  │  🎒 Инвентарь: %d/%d",
+			player.Gold, len(inv.GetItems()), inv.Capacity)), width)
+		b.WriteString("\n")
+	}
+
+	for i, item := range m.items {
+		line := fmt.Sprintf("%s (ур. %d, %s)", item.GetFullName(), item.Level, item.GetTypeName())
+		ui.CenteredLineBuilder(&b, ui.RenderMenuItem(i == m.selected && m.mode == lootModeList, ui.RarityStyle(item.Rarity).Render(line)), width)
+	}
+	if len(m.items) > 0 {
+		b.WriteString("\n")
+		for _, line := range strings.Split(itemDetail(m.gameManager.GetPlayer(), m.items[m.selected]), "\n") {
+			ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(line), width)
+		}
+	}
+
+	switch m.mode {
+	case lootModeReplace:
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(fmt.Sprintf("Что выбросить ради «%s»?", m.items[m.selected].GetFullName())), width)
+		if player != nil {
+			for i, item := range player.GetInventory().GetItems() {
+				ui.CenteredLineBuilder(&b, ui.RenderMenuItem(i == m.replace, item.GetFullName()), width)
+			}
+		}
+	case lootModeLeave:
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(fmt.Sprintf("Оставить %d предм. на поле боя? Y — да, N — нет", len(m.items))), width)
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(m.message), width)
+	}
+
+	help := "↑↓ Выбор  │  Enter Взять  │  A Взять всё  │  Q Уйти"
+	if m.mode == lootModeReplace {
+		help = "↑↓ Выбор  │  Enter Выбросить и взять  │  N Отмена"
+	}
+	b.WriteString("\n")
+	ui.CenteredLineBuilder(&b, ui.HelpStyle.Render(help), width)
+	return b.String()
+}
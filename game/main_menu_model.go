@@ -1,6 +1,7 @@
 package game
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"MyGame/EULA"
+	"MyGame/Struct/Character"
 	"MyGame/core"
 	"MyGame/game/ui"
 )
@@ -32,6 +34,28 @@ var menuTitleLines = []string{
 	"╚════════════════════════════════════════════════════════════════╝",
 }
 
+type menuEntry struct {
+	label  string
+	view   ViewType
+	resume bool
+}
+
+var mainMenuItems = []menuEntry{
+	{label: "Новая игра", view: ViewCreation},
+	{label: "Кампания", view: ViewCampaign},
+	{label: "Быстрый бой", view: ViewFight},
+	{label: "Подземелье", view: ViewDungeon},
+	{label: "Персонаж", view: ViewCharacter},
+	{label: "Задания", view: ViewQuests},
+	{label: "Торговец", view: ViewShop},
+	{label: "Мастерская", view: ViewWorkshop},
+	{label: "Сетевой бой (PvP)", view: ViewPvPConnect},
+	{label: "Чат", view: ViewChat},
+	{label: "Настройки", view: ViewSettings},
+	{label: "Лицензия", view: ViewEULA},
+	{label: "Выход", view: ViewExitConfirm},
+}
+
 type MainMenuModel struct {
 	gameManager   *core.ExtendedGameManager
 	selected      int
@@ -41,10 +65,13 @@ type MainMenuModel struct {
 	typingIndex   int
 	isTyping      bool
 	typingSpeed   time.Duration
+	LastOutcome   core.BattleOutcome
+	message       string
+	entries       []menuEntry
 }
 
 func NewMainMenuModel(gameCore *core.Core) *MainMenuModel {
-	return &MainMenuModel{
+	m := &MainMenuModel{
 		gameManager:   gameCore.ExtendedGameManager,
 		selected:      0,
 		Width:         80,
@@ -54,6 +81,8 @@ func NewMainMenuModel(gameCore *core.Core) *MainMenuModel {
 		isTyping:      true,
 		typingSpeed:   time.Millisecond,
 	}
+	m.Refresh()
+	return m
 }
 
 func (m *MainMenuModel) Init() tea.Cmd {
@@ -92,7 +121,7 @@ func (m *MainMenuModel) Update(msg tea.Msg) (*MainMenuModel, tea.Cmd) {
 					m.selected--
 				}
 			case "down", "j":
-				if m.selected < 4 {
+				if m.selected < len(m.entries)-1 {
 					m.selected++
 				}
 			case "enter", " ":
@@ -113,20 +142,37 @@ func (m *MainMenuModel) buildFullMenuText() string {
 	return b.String()
 }
 
+func (m *MainMenuModel) Refresh() {
+	var current menuEntry
+	if m.selected < len(m.entries) {
+		current = m.entries[m.selected]
+	}
+	m.entries = mainMenuItems
+	if core.HasSave() {
+		m.entries = append([]menuEntry{{label: "Продолжить", view: ViewCampaign, resume: true}}, mainMenuItems...)
+	}
+	m.selected = 0
+	for i, entry := range m.entries {
+		if entry == current {
+			m.selected = i
+		}
+	}
+}
+
 func (m *MainMenuModel) handleSelection() tea.Cmd {
-	switch m.selected {
-	case 0:
-		return func() tea.Msg { return ViewChangeMsg{ViewFight} }
-	case 1:
-		return func() tea.Msg { return ViewChangeMsg{ViewPvPConnect} }
-	case 2:
-		return func() tea.Msg { return ViewChangeMsg{ViewChat} }
-	case 3:
-		return func() tea.Msg { return ViewChangeMsg{ViewEULA} }
-	case 4:
-		return func() tea.Msg { return ViewChangeMsg{ViewExitConfirm} }
+	if m.selected >= len(m.entries) {
+		m.selected = len(m.entries) - 1
+		return nil
 	}
-	return nil
+	entry := m.entries[m.selected]
+	m.message = ""
+	if entry.resume {
+		if err := m.gameManager.LoadFromFile(); err != nil {
+			m.message = fmt.Sprintf("❌ %v", err)
+			return nil
+		}
+	}
+	return func() tea.Msg { return ViewChangeMsg{View: entry.view} }
 }
 
 func (m *MainMenuModel) View() string {
@@ -164,8 +210,8 @@ func (m *MainMenuModel) View() string {
 			b.WriteString("\n")
 		}
 
-		for i, item := range []string{"1. Быстрый бой", "2. Сетевой бой (PvP)", "3. Чат", "4. Лицензия", "5. Выход"} {
-			ui.CenteredLineBuilder(&b, ui.RenderMenuItem(i == m.selected, item), width)
+		for i, entry := range m.entries {
+			ui.CenteredLineBuilder(&b, ui.RenderMenuItem(i == m.selected, fmt.Sprintf("%d. %s", i+1, entry.label)), width)
 		}
 
 		helpTop := (m.Height * 82) / 100
@@ -173,12 +219,31 @@ func (m *MainMenuModel) View() string {
 		for i := 0; i < max(0, helpTop-currentLines); i++ {
 			b.WriteString("\n")
 		}
+		if player := m.gameManager.GetPlayer(); player != nil {
+			name := player.GetName()
+			if class, ok := Character.ClassByID(player.Class); ok {
+				name += ", " + class.Name
+			}
+			ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(fmt.Sprintf("%s — уровень %d, опыт %d/%d",
+				name, player.GetLevel(), player.XP, m.gameManager.GetConfig().XPForLevel(player.GetLevel()))), width)
+		}
+		if m.message != "" {
+			ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(m.message), width)
+		}
+		if m.LastOutcome != core.OutcomeNone {
+			ui.CenteredLineBuilder(&b, ui.WarningStyle.Render("Итог последнего боя: "+m.LastOutcome.String()), width)
+		}
 		ui.CenteredLineBuilder(&b, ui.HelpStyle.Render("↑↓ Навигация  │  Enter Выбор  │  ESC Выход"), width)
 	}
 	return b.String()
 }
 
-type ViewChangeMsg struct{ View ViewType }
+type ViewChangeMsg struct {
+	View      ViewType
+	Outcome   core.BattleOutcome
+	Encounter string
+	Origin    core.GameState
+}
 type ViewType int
 
 const (
@@ -191,6 +256,16 @@ const (
 	ViewFullEULA
 	ViewPvPConnect
 	ViewPvPFight
+	ViewSettings
+	ViewLevelUp
+	ViewCharacter
+	ViewLoot
+	ViewShop
+	ViewWorkshop
+	ViewCreation
+	ViewCampaign
+	ViewDungeon
+	ViewQuests
 )
 const SkipEULA = true
 
@@ -224,7 +299,7 @@ func (m *EULAModel) Update(msg tea.Msg) (*EULAModel, tea.Cmd) {
 		case "enter", " ":
 			return m, m.handleSelection()
 		case "esc":
-			return m, func() tea.Msg { return ViewChangeMsg{ViewMainMenu} }
+			return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
 		}
 	}
 	return m, nil
@@ -238,7 +313,7 @@ func (m *EULAModel) handleSelection() tea.Cmd {
 		}
 		fallthrough
 	case 2:
-		return func() tea.Msg { return ViewChangeMsg{ViewMainMenu} }
+		return func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
 	case 1:
 		return tea.Quit
 	}
@@ -344,7 +419,7 @@ func (m *FullEULAModel) handleSelection() tea.Cmd {
 		if m.gameManager != nil && m.gameManager.Deps != nil && m.gameManager.Deps.GetLogger() != nil {
 			m.gameManager.Deps.GetLogger().GameEvent("eula", "лицензия принята при запуске")
 		}
-		return func() tea.Msg { return ViewChangeMsg{ViewMainMenu} }
+		return func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
 	case 1:
 		return tea.Quit
 	}
@@ -3,6 +3,7 @@ package game
 import (
 	"fmt"
 	"io"
+	"math/rand"
 	"net"
 	"os"
 	"strings"
@@ -18,6 +19,8 @@ import (
 	"MyGame/sound"
 
 	"MyGame/Struct/Item"
+	"MyGame/combat"
+	"MyGame/config"
 	"MyGame/game/ui"
 	"MyGame/utils"
 )
@@ -122,7 +125,9 @@ type pvpHideMessageMsg struct{}
 
 var pvpSwordIDs = []int{1, 24, 25, 26}
 
-const pvpHP, pvpStr, pvpAgl, pvpInt = 100, 14, 7, 4
+const pvpEndReasonHP = "hp"
+
+const pvpStr, pvpAgl, pvpInt = 14, 7, 4
 
 func fixRunesForWindows(runes []rune) []rune {
 	if len(runes) == 0 {
@@ -156,6 +161,7 @@ type PvPFightModel struct {
 	p2              *Character.Character
 	round           int
 	turn            int
+	order           []int
 	state           FightViewState
 	selected        int
 	message         string
@@ -169,6 +175,8 @@ type PvPFightModel struct {
 	itemManager     *ItemEffectManager
 	gameOver        bool
 	winnerSide      int
+	endReason       string
+	limit           combat.RoundLimit
 	itemSelected    int
 	waitingForMatch bool
 	connectionErr   string
@@ -176,14 +184,14 @@ type PvPFightModel struct {
 	waitingForState bool
 }
 
-func NewPvPFightModel(session *Session) *PvPFightModel {
-	th := NewTurnHandler()
+func NewPvPFightModel(session *Session, rng *rand.Rand) *PvPFightModel {
+	th := NewTurnHandler(rng)
 	iem := NewItemEffectManager()
-	p1, _ := Character.New("Игрок1", pvpHP, pvpStr, pvpAgl, pvpInt)
-	p2, _ := Character.New("Игрок2", pvpHP, pvpStr, pvpAgl, pvpInt)
+	p1, _ := Character.New("Игрок1", config.PvPHP, pvpStr, pvpAgl, pvpInt)
+	p2, _ := Character.New("Игрок2", config.PvPHP, pvpStr, pvpAgl, pvpInt)
 	p1.CalculateStats()
 	p2.CalculateStats()
-	return &PvPFightModel{
+	m := &PvPFightModel{
 		session:         session,
 		MySide:          0,
 		p1:              p1,
@@ -200,7 +208,12 @@ func NewPvPFightModel(session *Session) *PvPFightModel {
 		Width:           ui.MinWidth,
 		Height:          ui.MinHeight,
 		waitingForMatch: true,
+		limit:           roundLimitFor(nil, config.ModePvP),
 	}
+	th.Pipeline().Use(combat.StageResist, m.limit.Modifier(func() int { return m.round }))
+	th.Pipeline().Clear(combat.StageInjury)
+	th.Pipeline().Clear(combat.StageWear)
+	return m
 }
 
 func readPvPLineCmd(session *Session) tea.Cmd {
@@ -241,10 +254,16 @@ func (m *PvPFightModel) equipPvPWeapon() {
 	_ = m.player.EquipItem(pvpSwordIDs[0])
 	m.player.CalculateStats()
 	m.weaponEquipped = true
+	m.sendStats()
+}
+
+func (m *PvPFightModel) sendStats() {
+	_ = m.pvpSend(SerializeStats(Stats{Agility: m.player.GetAgility(), AttackSpeed: m.player.GetAttackSpeed(), MaxHP: m.player.GetMaxHP()}))
 }
 
 func (m *PvPFightModel) applyState(s State) {
 	m.round = s.Round
+	m.order = s.Order
 	if m.MySide == 1 {
 		m.player.SetHP(s.P1HP)
 		m.enemy.SetHP(s.P2HP)
@@ -328,8 +347,6 @@ func (m *PvPFightModel) sendState() {
 		return
 	}
 
-	m.turn = 3 - m.MySide
-	s.Turn = m.turn
 	m.waitingForState = true
 	_ = m.pvpSend(SerializeState(s))
 }
@@ -394,10 +411,14 @@ func (m *PvPFightModel) Update(msg tea.Msg) (*PvPFightModel, tea.Cmd) {
 					m.enemy.SetHP(init.P1HP)
 				}
 				m.round = init.Round
+				if init.Rounds > 0 {
+					m.limit = combat.NewRoundLimit(init.Rounds, config.RoundRule(init.Rule))
+				}
 				if init.Turn == 1 || init.Turn == 2 {
 					m.turn = init.Turn
 				} else {
-					m.turn = 1
+					m.turn = 0
+					m.waitingForState = true
 				}
 				m.waitingForMatch = false
 				m.equipPvPWeapon()
@@ -436,6 +457,7 @@ func (m *PvPFightModel) Update(msg tea.Msg) (*PvPFightModel, tea.Cmd) {
 			if !m.gameOver {
 				m.gameOver = true
 				m.winnerSide = e.Winner
+				m.endReason = e.Reason
 				m.state = FightViewEnd
 				if m.session != nil {
 					_ = m.session.Close()
@@ -559,10 +581,14 @@ func (m *PvPFightModel) updatePvPActionMenu(msg tea.KeyMsg) (*PvPFightModel, tea
 			if attackPart == blockPart {
 				m.message = fmt.Sprintf("🛡️ %s заблокировал удар!", m.enemy.GetName())
 			} else {
-				dmg = m.turnHandler.CalculateDamage(m.player, m.enemy, attackPart)
-				m.enemy.TakeDamage(dmg)
-				m.message = fmt.Sprintf("💥 Вы нанесли %d урона! %s: %d/%d HP",
-					dmg, m.enemy.GetName(), m.enemy.GetHP(), m.enemy.GetMaxHP())
+				res := m.turnHandler.ApplyHit(m.player, m.enemy, attackPart, 1.0)
+				dmg = res.Damage
+				if res.Dodged {
+					m.message = fmt.Sprintf("💨 %s увернулся!", m.enemy.GetName())
+				} else {
+					m.message = fmt.Sprintf("💥 Вы нанесли %d урона! %s: %d/%d HP",
+						dmg, m.enemy.GetName(), m.enemy.GetHP(), m.enemy.GetMaxHP()) + strikeExtras(res)
+				}
 			}
 
 			_ = m.pvpSend(SerializeAction(Action{
@@ -573,7 +599,6 @@ func (m *PvPFightModel) updatePvPActionMenu(msg tea.KeyMsg) (*PvPFightModel, tea
 			}))
 
 			m.showMessage = true
-			m.round++
 			m.sendState()
 
 			if m.gameOver {
@@ -638,7 +663,6 @@ func (m *PvPFightModel) updatePvPItemMenu(msg tea.KeyMsg) (*PvPFightModel, tea.C
 				m.state = FightViewActionMenu
 
 				_ = m.pvpSend(SerializeAction(Action{Kind: "item", ItemIdx: m.itemSelected}))
-				m.round++
 				m.sendState()
 
 				if m.gameOver {
@@ -659,6 +683,7 @@ func (m *PvPFightModel) updatePvPItemMenu(msg tea.KeyMsg) (*PvPFightModel, tea.C
 			if toEquip != nil && toEquip.Template != nil {
 				_ = m.player.EquipItem(toEquip.Template.ID)
 				m.player.CalculateStats()
+				m.sendStats()
 				m.message = "Экипирован: " + toEquip.Template.Name
 				m.showMessage = true
 				m.state = FightViewActionMenu
@@ -714,14 +739,19 @@ func (m *PvPFightModel) updatePvPExitConfirm(msg tea.KeyMsg) (*PvPFightModel, te
 }
 
 func (m *PvPFightModel) getPvPItemLists() (usable []*Item.Item, equippable []*Item.Item) {
-	usable = m.itemManager.GetUsableItems(m.player.GetInventory().GetItems())
+	for _, item := range m.itemManager.GetUsableItems(m.player.GetInventory().GetItems()) {
+		if !m.itemManager.IsEscapeItem(item) {
+			usable = append(usable, item)
+		}
+	}
 	equippable = m.player.GetInventory().FindEquippableItems()
 	return usable, equippable
 }
 
 func (m *PvPFightModel) getPvPStats() string {
-	return fmt.Sprintf("📊 Раунд %d  │  Вы: %d/%d  │  Соперник: %d/%d",
-		m.round, m.player.GetHP(), m.player.GetMaxHP(), m.enemy.GetHP(), m.enemy.GetMaxHP())
+	return fmt.Sprintf("📊 %s  │  Вы: %d/%d  │  Соперник: %d/%d  │  Крит %.0f%%  │  Уклонение %.0f%%  │  Вампиризм %.0f%%",
+		m.limit.Label(m.round), m.player.GetHP(), m.player.GetMaxHP(), m.enemy.GetHP(), m.enemy.GetMaxHP(),
+		m.player.GetCritChance()*100, m.player.GetEvasion()*100, m.player.GetLifesteal()*100)
 }
 
 func (m *PvPFightModel) View() string {
@@ -749,7 +779,7 @@ func (m *PvPFightModel) renderPvPBattleScreen() string {
 		b.WriteString(m.centerPvPText("Поиск противника... ESC — отмена", w))
 		return b.String()
 	}
-	title := "⚔️ PvP  РАУНД " + fmt.Sprintf("%d", m.round)
+	title := "⚔️ PvP  " + m.limit.Label(m.round)
 	var status string
 	if m.waitingForState {
 		status = "  │  Ожидание ответа противника"
@@ -760,6 +790,10 @@ func (m *PvPFightModel) renderPvPBattleScreen() string {
 	}
 	title += status
 	b.WriteString(m.centerPvPText(lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorTitle)).Bold(true).Render(title), w))
+	b.WriteString("\n")
+	if queue := m.upcomingTurns(); queue != "" {
+		b.WriteString(m.centerPvPText(lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorHelp)).Render(queue), w))
+	}
 	b.WriteString("\n\n")
 
 	enemyName := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorDanger)).Bold(true).Render("◆ " + m.enemy.GetName())
@@ -818,6 +852,24 @@ func (m *PvPFightModel) renderPvPBattleScreen() string {
 	return b.String()
 }
 
+func (m *PvPFightModel) upcomingTurns() string {
+	if m.turn != 1 && m.turn != 2 {
+		return ""
+	}
+	names := []string{"▶ " + m.sideName(m.turn)}
+	for _, side := range m.order {
+		names = append(names, m.sideName(side))
+	}
+	return "⏳ Очередь: " + strings.Join(names, " → ")
+}
+
+func (m *PvPFightModel) sideName(side int) string {
+	if side == m.MySide {
+		return "Вы"
+	}
+	return m.enemy.GetName()
+}
+
 func (m *PvPFightModel) centerPvPText(text string, width int) string {
 	r := []rune(text)
 	if len(r) >= width {
@@ -873,9 +925,15 @@ func (m *PvPFightModel) renderPvPEndScreen() string {
 		w = ui.MinWidth
 	}
 	title := "💀 Поражение"
-	if m.winnerSide == m.MySide {
+	switch {
+	case m.winnerSide == 0:
+		title = "🤝 Ничья: лимит раундов исчерпан"
+	case m.winnerSide == m.MySide:
 		title = "🎉 Победа!"
 	}
+	if m.winnerSide != 0 && m.endReason == pvpEndReasonHP {
+		title += " (по доле HP после лимита раундов)"
+	}
 	b.WriteString(m.centerPvPText(title, w))
 	b.WriteString("\n\n")
 	b.WriteString(m.centerPvPText("Через 2 сек — в меню", w))
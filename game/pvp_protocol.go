@@ -17,6 +17,7 @@ const (
 	MsgAction = "ACTION"
 	MsgChat   = "CHAT"
 	MsgEnd    = "END"
+	MsgStats  = "STATS"
 )
 
 type Init struct {
@@ -28,6 +29,8 @@ type Init struct {
 	P2Max  int
 	Round  int
 	Turn   int
+	Rounds int
+	Rule   string
 }
 
 type State struct {
@@ -35,6 +38,13 @@ type State struct {
 	P1HP  int
 	P2HP  int
 	Turn  int
+	Order []int
+}
+
+type Stats struct {
+	Agility     int
+	AttackSpeed float32
+	MaxHP       int
 }
 
 type Action struct {
@@ -47,6 +57,8 @@ type Action struct {
 
 type End struct {
 	Winner int
+	Draw   bool
+	Reason string
 }
 
 type Session struct {
@@ -75,14 +87,22 @@ func ParseInit(line string) (Init, error) {
 			i.Round, _ = strconv.Atoi(part[6:])
 		} else if strings.HasPrefix(part, "turn=") {
 			i.Turn, _ = strconv.Atoi(part[5:])
+		} else if strings.HasPrefix(part, "rounds=") {
+			i.Rounds, _ = strconv.Atoi(part[7:])
+		} else if strings.HasPrefix(part, "rule=") {
+			i.Rule = part[5:]
 		}
 	}
 	return i, nil
 }
 
 func SerializeInit(i Init) string {
-	return fmt.Sprintf("INIT p1name=%s p1hp=%d p1max=%d p2name=%s p2hp=%d p2max=%d round=%d turn=%d",
+	line := fmt.Sprintf("INIT p1name=%s p1hp=%d p1max=%d p2name=%s p2hp=%d p2max=%d round=%d turn=%d",
 		i.P1Name, i.P1HP, i.P1Max, i.P2Name, i.P2HP, i.P2Max, i.Round, i.Turn)
+	if i.Rounds > 0 {
+		line += fmt.Sprintf(" rounds=%d rule=%s", i.Rounds, i.Rule)
+	}
+	return line
 }
 
 func ParseState(line string) (State, error) {
@@ -96,13 +116,47 @@ func ParseState(line string) (State, error) {
 			s.P2HP, _ = strconv.Atoi(part[5:])
 		} else if strings.HasPrefix(part, "turn=") {
 			s.Turn, _ = strconv.Atoi(part[5:])
+		} else if strings.HasPrefix(part, "order=") {
+			for _, side := range strings.Split(part[6:], ",") {
+				if v, err := strconv.Atoi(side); err == nil {
+					s.Order = append(s.Order, v)
+				}
+			}
 		}
 	}
 	return s, nil
 }
 
 func SerializeState(s State) string {
-	return fmt.Sprintf("STATE round=%d p1hp=%d p2hp=%d turn=%d", s.Round, s.P1HP, s.P2HP, s.Turn)
+	line := fmt.Sprintf("STATE round=%d p1hp=%d p2hp=%d turn=%d", s.Round, s.P1HP, s.P2HP, s.Turn)
+	if len(s.Order) > 0 {
+		order := make([]string, len(s.Order))
+		for i, side := range s.Order {
+			order[i] = strconv.Itoa(side)
+		}
+		line += " order=" + strings.Join(order, ",")
+	}
+	return line
+}
+
+func ParseStats(line string) (Stats, error) {
+	st := Stats{AttackSpeed: 1}
+	for _, part := range strings.Fields(line) {
+		if strings.HasPrefix(part, "agility=") {
+			st.Agility, _ = strconv.Atoi(part[8:])
+		} else if strings.HasPrefix(part, "speed=") {
+			if v, err := strconv.ParseFloat(part[6:], 32); err == nil {
+				st.AttackSpeed = float32(v)
+			}
+		} else if strings.HasPrefix(part, "maxhp=") {
+			st.MaxHP, _ = strconv.Atoi(part[6:])
+		}
+	}
+	return st, nil
+}
+
+func SerializeStats(st Stats) string {
+	return fmt.Sprintf("STATS agility=%d speed=%.2f maxhp=%d", st.Agility, st.AttackSpeed, st.MaxHP)
 }
 
 func ParseAction(line string) (Action, error) {
@@ -146,14 +200,24 @@ func ParseEnd(line string) (End, error) {
 	for _, part := range strings.Fields(line) {
 		if strings.HasPrefix(part, "winner=") {
 			e.Winner, _ = strconv.Atoi(part[7:])
-			break
+		} else if strings.HasPrefix(part, "draw=") {
+			e.Draw = part[5:] == "1"
+		} else if strings.HasPrefix(part, "reason=") {
+			e.Reason = part[7:]
 		}
 	}
 	return e, nil
 }
 
 func SerializeEnd(e End) string {
-	return fmt.Sprintf("END winner=%d", e.Winner)
+	line := fmt.Sprintf("END winner=%d", e.Winner)
+	if e.Draw {
+		line += " draw=1"
+	}
+	if e.Reason != "" {
+		line += " reason=" + e.Reason
+	}
+	return line
 }
 
 func NewSession(conn net.Conn) *Session {
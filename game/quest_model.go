@@ -0,0 +1,137 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"MyGame/Struct/Quest"
+	"MyGame/core"
+	"MyGame/game/ui"
+)
+
+type QuestModel struct {
+	gameManager *core.ExtendedGameManager
+	journal     *Quest.Journal
+	selected    int
+	message     string
+	Width       int
+	Height      int
+}
+
+func NewQuestModel(gameManager *core.ExtendedGameManager) *QuestModel {
+	return &QuestModel{
+		gameManager: gameManager,
+		journal:     gameManager.QuestJournal(),
+		Width:       ui.MinWidth,
+		Height:      ui.MinHeight,
+	}
+}
+
+func (m *QuestModel) Update(msg tea.Msg) (*QuestModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.selected > 0 {
+			m.selected--
+		}
+	case "down", "j":
+		if m.selected < len(m.gameManager.Quests)-1 {
+			m.selected++
+		}
+	case "enter", " ":
+		return m, m.turnIn()
+	case "q", "й":
+		return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+	}
+	return m, nil
+}
+
+func (m *QuestModel) turnIn() tea.Cmd {
+	if m.selected >= len(m.gameManager.Quests) {
+		return nil
+	}
+	q := m.gameManager.Quests[m.selected]
+	ups, err := m.gameManager.TurnInQuest(q)
+	if err != nil {
+		m.message = fmt.Sprintf("❌ %v", err)
+		return nil
+	}
+	m.message = fmt.Sprintf("🏆 Награда за «%s»: %s", q.Title, q.Reward)
+	if len(ups) > 0 {
+		return func() tea.Msg { return ViewChangeMsg{View: ViewLevelUp} }
+	}
+	return nil
+}
+
+func (m *QuestModel) status(q *Quest.Quest) string {
+	switch {
+	case m.journal.TurnedIn[q.ID]:
+		return "✓"
+	case m.journal.Complete(q, m.gameManager.QuestHoldings()):
+		return "★"
+	}
+	return "○"
+}
+
+func (m *QuestModel) View() string {
+	var b strings.Builder
+	width := max(m.Width, ui.MinWidth)
+
+	for i := 0; i < max(0, (m.Height-30)/3); i++ {
+		b.WriteString("\n")
+	}
+
+	titleStyle := ui.TitleStyle.Copy().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(ui.ColorBorder)).Padding(0, 1)
+	ui.CenteredLineBuilder(&b, titleStyle.Render("📖 ЖУРНАЛ ЗАДАНИЙ"), width)
+	b.WriteString("\n")
+
+	quests := m.gameManager.Quests
+	done := 0
+	for _, q := range quests {
+		if m.journal.TurnedIn[q.ID] {
+			done++
+		}
+	}
+	statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorStats))
+	ui.CenteredLineBuilder(&b, statsStyle.Render(fmt.Sprintf("Сдано заданий: %d из %d  │  ○ в процессе  ★ можно сдать  ✓ сдано", done, len(quests))), width)
+	b.WriteString("\n")
+
+	if len(quests) == 0 {
+		ui.CenteredLineBuilder(&b, ui.NormalStyle.Render("Заданий нет"), width)
+	}
+	for i, q := range quests {
+		ui.CenteredLineBuilder(&b, ui.RenderMenuItem(i == m.selected, fmt.Sprintf("%s %s", m.status(q), q.Title)), width)
+	}
+
+	if m.selected < len(quests) {
+		q := quests[m.selected]
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(q.Description), width)
+		have := m.gameManager.QuestHoldings()
+		for i, o := range q.Objectives {
+			count := m.journal.Count(q, i, have)
+			line := fmt.Sprintf("%s: %d/%d", o.Text, count, o.Count)
+			if count >= o.Count {
+				ui.CenteredLineBuilder(&b, statsStyle.Render("✔ "+line), width)
+			} else {
+				ui.CenteredLineBuilder(&b, ui.NormalStyle.Render("• "+line), width)
+			}
+		}
+		ui.CenteredLineBuilder(&b, ui.TitleStyle.Render("Награда: "+q.Reward.String()), width)
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(m.message), width)
+	}
+
+	b.WriteString("\n")
+	ui.CenteredLineBuilder(&b, ui.HelpStyle.Render("↑↓ Выбор  │  Enter Сдать задание  │  Q Выход"), width)
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"MyGame/Struct/Bestiary"
+	"MyGame/config"
+	"MyGame/core"
+	"MyGame/game/ui"
+)
+
+type SettingsModel struct {
+	gameManager *core.ExtendedGameManager
+	profiles    []config.DifficultyProfile
+	selected    int
+	message     string
+	Width       int
+	Height      int
+}
+
+func NewSettingsModel(gameManager *core.ExtendedGameManager) *SettingsModel {
+	m := &SettingsModel{
+		gameManager: gameManager,
+		profiles:    config.Difficulties(),
+		Width:       ui.MinWidth,
+		Height:      ui.MinHeight,
+	}
+	current := m.current()
+	for i, p := range m.profiles {
+		if p.ID == current.ID {
+			m.selected = i
+		}
+	}
+	return m
+}
+
+func (m *SettingsModel) current() config.DifficultyProfile {
+	if m.gameManager == nil {
+		return config.DifficultyFor("")
+	}
+	return m.gameManager.GetConfig().DifficultyProfile()
+}
+
+func (m *SettingsModel) Update(msg tea.Msg) (*SettingsModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.selected > 0 {
+			m.selected--
+		}
+	case "down", "j":
+		if m.selected < len(m.profiles)-1 {
+			m.selected++
+		}
+	case "enter", " ":
+		if m.gameManager == nil {
+			return m, nil
+		}
+		profile := m.profiles[m.selected]
+		if err := m.gameManager.SetDifficulty(string(profile.ID)); err != nil {
+			m.message = fmt.Sprintf("❌ %v", err)
+			return m, nil
+		}
+		m.message = fmt.Sprintf("✅ Сложность: %s", profile.Name)
+	case "esc", "q":
+		return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+	}
+	return m, nil
+}
+
+func (m *SettingsModel) View() string {
+	var b strings.Builder
+	width := max(m.Width, ui.MinWidth)
+
+	for i := 0; i < max(0, (m.Height-20)/3); i++ {
+		b.WriteString("\n")
+	}
+
+	titleStyle := ui.TitleStyle.Copy().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(ui.ColorBorder)).Padding(0, 1)
+	ui.CenteredLineBuilder(&b, titleStyle.Render("⚙️ НАСТРОЙКИ — СЛОЖНОСТЬ"), width)
+	b.WriteString("\n")
+
+	current := m.current()
+	for i, p := range m.profiles {
+		mark := "  "
+		if p.ID == current.ID {
+			mark = "✔ "
+		}
+		ui.CenteredLineBuilder(&b, ui.RenderMenuItem(i == m.selected, mark+p.Name), width)
+	}
+	b.WriteString("\n")
+
+	p := m.profiles[m.selected]
+	statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorStats))
+	ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(p.Description), width)
+	ai := "по бестиарию"
+	if p.EnemyAI != "" {
+		ai = strings.ToLower(Bestiary.AIProfile(p.EnemyAI).DisplayName())
+	}
+	ui.CenteredLineBuilder(&b, statsStyle.Render(fmt.Sprintf("Противники: HP ×%.2f  │  Сила ×%.2f  │  Ловкость ×%.2f  │  Поведение: %s  │  Зелья: %d",
+		p.EnemyHP, p.EnemyStrength, p.EnemyAgility, ai, p.EnemyPotions)), width)
+	ui.CenteredLineBuilder(&b, statsStyle.Render(fmt.Sprintf("Вы: зелий на бой %d  │  Добыча: шанс %.0f%%, редкость +%d",
+		p.PlayerPotions, p.LootChance*100, p.LootRarityBonus)), width)
+
+	if m.message != "" {
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(m.message), width)
+	}
+
+	b.WriteString("\n")
+	ui.CenteredLineBuilder(&b, ui.HelpStyle.Render("↑↓ Выбор  │  Enter Применить  │  ESC Назад"), width)
+	return b.String()
+}
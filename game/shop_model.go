@@ -0,0 +1,203 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"MyGame/Struct/Item"
+	"MyGame/Struct/Shop"
+	"MyGame/core"
+	"MyGame/game/ui"
+)
+
+type shopTab int
+
+const (
+	shopTabBuy shopTab = iota
+	shopTabSell
+	shopTabBuyback
+	shopTabRepair
+)
+
+var shopTabNames = []string{"Купить", "Продать", "Выкуп", "Ремонт"}
+
+type ShopModel struct {
+	gameManager *core.ExtendedGameManager
+	shop        *Shop.Shop
+	tab         shopTab
+	selected    int
+	message     string
+	Width       int
+	Height      int
+}
+
+func NewShopModel(gameManager *core.ExtendedGameManager) *ShopModel {
+	return &ShopModel{
+		gameManager: gameManager,
+		shop:        gameManager.OpenShop(),
+		Width:       ui.MinWidth,
+		Height:      ui.MinHeight,
+	}
+}
+
+func (m *ShopModel) items() []*Item.Item {
+	switch m.tab {
+	case shopTabSell:
+		if player := m.gameManager.GetPlayer(); player != nil {
+			return player.GetInventory().GetItems()
+		}
+		return nil
+	case shopTabBuyback:
+		return m.shop.Buyback
+	case shopTabRepair:
+		if player := m.gameManager.GetPlayer(); player != nil {
+			return Shop.Repairable(player)
+		}
+		return nil
+	}
+	return m.shop.Stock
+}
+
+func (m *ShopModel) price(item *Item.Item) int {
+	switch m.tab {
+	case shopTabBuy:
+		return item.Price
+	case shopTabRepair:
+		return item.RepairCost()
+	}
+	return Shop.SellPrice(item)
+}
+
+func (m *ShopModel) Update(msg tea.Msg) (*ShopModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "left", "h":
+		m.tab = (m.tab + shopTab(len(shopTabNames)) - 1) % shopTab(len(shopTabNames))
+		m.selected = 0
+	case "right", "l", "tab":
+		m.tab = (m.tab + 1) % shopTab(len(shopTabNames))
+		m.selected = 0
+	case "up", "k":
+		if m.selected > 0 {
+			m.selected--
+		}
+	case "down", "j":
+		if m.selected < len(m.items())-1 {
+			m.selected++
+		}
+	case "enter", " ":
+		m.trade()
+	case "q", "й":
+		return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+	}
+	return m, nil
+}
+
+func (m *ShopModel) trade() {
+	player := m.gameManager.GetPlayer()
+	if player == nil || len(m.items()) == 0 {
+		return
+	}
+	var (
+		item *Item.Item
+		err  error
+	)
+	switch m.tab {
+	case shopTabSell:
+		var price int
+		item, price, err = m.shop.Sell(player, m.selected)
+		if err == nil {
+			m.message = fmt.Sprintf("💰 Продано: %s за %d зол.", item.GetFullName(), price)
+		}
+	case shopTabRepair:
+		item = m.items()[m.selected]
+		var price int
+		price, err = Shop.Repair(player, item)
+		if err == nil {
+			m.message = fmt.Sprintf("🔧 Отремонтировано: %s за %d зол.", item.GetFullName(), price)
+		}
+	case shopTabBuyback:
+		item, err = m.shop.BuyBack(player, m.selected)
+		if err == nil {
+			err = m.gameManager.GiveItem(item)
+			m.message = fmt.Sprintf("↩️ Выкуплено: %s", item.GetFullName())
+		}
+	default:
+		item, err = m.shop.Buy(player, m.selected)
+		if err == nil {
+			err = m.gameManager.GiveItem(item)
+			m.message = fmt.Sprintf("✅ Куплено: %s", item.GetFullName())
+		}
+	}
+	if err != nil {
+		m.message = fmt.Sprintf("❌ %v", err)
+	} else {
+		m.gameManager.AutoSave()
+	}
+	m.selected = min(m.selected, max(len(m.items())-1, 0))
+}
+
+func (m *ShopModel) View() string {
+	var b strings.Builder
+	width := max(m.Width, ui.MinWidth)
+
+	for i := 0; i < max(0, (m.Height-30)/3); i++ {
+		b.WriteString("\n")
+	}
+
+	titleStyle := ui.TitleStyle.Copy().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(ui.ColorBorder)).Padding(0, 1)
+	ui.CenteredLineBuilder(&b, titleStyle.Render("⚖️ ТОРГОВЕЦ"), width)
+	b.WriteString("\n")
+
+	if player := m.gameManager.GetPlayer(); player != nil {
+		inv := player.GetInventory()
+		statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorStats))
+		ui.CenteredLineBuilder(&b, statsStyle.Render(fmt.Sprintf("💰 Золото: %d  │  🎒 Инвентарь: %d/%d  │  🔄 Новый товар после боя №%d",
+			player.Gold, len(inv.GetItems()), inv.Capacity, m.shop.NextRotation())), width)
+		b.WriteString("\n")
+	}
+
+	tabs := make([]string, len(shopTabNames))
+	for i, name := range shopTabNames {
+		if shopTab(i) == m.tab {
+			tabs[i] = ui.SelectedStyle.Render("[ " + name + " ]")
+		} else {
+			tabs[i] = ui.NormalStyle.Render("  " + name + "  ")
+		}
+	}
+	ui.CenteredLineBuilder(&b, strings.Join(tabs, " "), width)
+	b.WriteString("\n")
+
+	items := m.items()
+	if len(items) == 0 {
+		ui.CenteredLineBuilder(&b, ui.NormalStyle.Render("Пусто"), width)
+	}
+	for i, item := range items {
+		line := fmt.Sprintf("%s (ур. %d, %s) — %d зол.", item.GetFullName(), item.Level, item.GetTypeName(), m.price(item))
+		if m.tab == shopTabRepair {
+			line = fmt.Sprintf("%s (прочность %d/%d) — %d зол.", item.GetFullName(), item.Durability, item.MaxDurability, m.price(item))
+		}
+		ui.CenteredLineBuilder(&b, ui.RenderMenuItem(i == m.selected, ui.RarityStyle(item.Rarity).Render(line)), width)
+	}
+	if m.selected < len(items) {
+		b.WriteString("\n")
+		for _, line := range strings.Split(itemDetail(m.gameManager.GetPlayer(), items[m.selected]), "\n") {
+			ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(line), width)
+		}
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(m.message), width)
+	}
+
+	b.WriteString("\n")
+	ui.CenteredLineBuilder(&b, ui.HelpStyle.Render("←→ Раздел  │  ↑↓ Выбор  │  Enter Сделка  │  Q Выход"), width)
+	return b.String()
+}
@@ -0,0 +1,326 @@
+package game
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	icharacter "MyGame/Interface"
+	"MyGame/Struct/Bestiary"
+	"MyGame/Struct/Character"
+	"MyGame/Struct/Item"
+	"MyGame/combat"
+	"MyGame/config"
+)
+
+const defaultSimulationActions = 500
+
+type FighterSpec struct {
+	Kind    string
+	Loadout []int
+}
+
+type SimulationConfig struct {
+	A          FighterSpec
+	B          FighterSpec
+	Fights     int
+	Seed       int64
+	MaxActions int
+	Difficulty config.DifficultyProfile
+	Game       *config.GameConfig
+}
+
+type SideStats struct {
+	Name      string
+	Wins      int
+	Attacks   int
+	Hits      int
+	Crits     int
+	Dodged    int
+	Blocked   int
+	SelfHits  int
+	Damage    []int
+	TotalDmg  int
+	Lifesteal int
+	Regen     int
+}
+
+type SimulationReport struct {
+	Config      SimulationConfig
+	Sides       [2]*SideStats
+	Draws       int
+	TotalRounds int
+}
+
+func ParseFighterSpec(kind, loadout string) (FighterSpec, error) {
+	spec := FighterSpec{Kind: strings.ToLower(strings.TrimSpace(kind))}
+	if spec.Kind == "" {
+		return spec, fmt.Errorf("не указан боец")
+	}
+	for _, part := range strings.Split(loadout, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return spec, fmt.Errorf("некорректный ID предмета '%s'", part)
+		}
+		spec.Loadout = append(spec.Loadout, id)
+	}
+	return spec, nil
+}
+
+func newSimulationFighter(spec FighterSpec, bestiary *Bestiary.Bestiary, difficulty config.DifficultyProfile, rng *rand.Rand) (*Combatant, error) {
+	var (
+		char *Character.Character
+		def  *Bestiary.Enemy
+		err  error
+	)
+	switch spec.Kind {
+	case "warrior", "воин":
+		char, err = Character.NewWarrior("Воин")
+	case "mage", "маг":
+		char, err = Character.NewMage("Маг")
+	case "rogue", "разбойник":
+		char, err = Character.NewRogue("Разбойник")
+	default:
+		def = adjustedEnemy(bestiary.Get(spec.Kind), difficulty)
+		if def == nil {
+			return nil, fmt.Errorf("неизвестный боец '%s': ожидается warrior, mage, rogue или id из бестиария", spec.Kind)
+		}
+		char, err = def.NewCharacter()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range spec.Loadout {
+		item, err := Item.CreateItem(id, Item.Common, 1)
+		if err != nil {
+			return nil, err
+		}
+		if char.Equipment.GetItem(item.Template.Slot) != nil {
+			_, _ = char.Equipment.Unequip(item.Template.Slot)
+		}
+		if err := char.Equipment.Equip(item); err != nil {
+			return nil, err
+		}
+	}
+	char.CalculateStats()
+	char.SetRNG(rng)
+
+	c := &Combatant{Char: char, Def: def}
+	if def != nil {
+		c.AI = NewEnemyAI(def.AI, rng)
+	}
+	return c, nil
+}
+
+func RunSimulation(cfg SimulationConfig, bestiary *Bestiary.Bestiary) (*SimulationReport, error) {
+	if cfg.Fights <= 0 {
+		return nil, fmt.Errorf("количество боёв должно быть положительным")
+	}
+	if cfg.MaxActions <= 0 {
+		cfg.MaxActions = defaultSimulationActions
+	}
+	if cfg.Difficulty.ID == "" {
+		cfg.Difficulty = config.DifficultyFor("")
+	}
+
+	report := &SimulationReport{Config: cfg}
+	limit := roundLimitFor(cfg.Game, config.ModePvE)
+	for i := 0; i < cfg.Fights; i++ {
+		rng := rand.New(rand.NewSource(cfg.Seed + int64(i)))
+		a, err := newSimulationFighter(cfg.A, bestiary, cfg.Difficulty, rng)
+		if err != nil {
+			return nil, err
+		}
+		b, err := newSimulationFighter(cfg.B, bestiary, cfg.Difficulty, rng)
+		if err != nil {
+			return nil, err
+		}
+		if report.Sides[0] == nil {
+			report.Sides[0] = &SideStats{Name: "A: " + a.Char.GetName()}
+			report.Sides[1] = &SideStats{Name: "B: " + b.Char.GetName()}
+		}
+		report.runFight(NewTurnHandler(rng), [2]*Combatant{a, b}, limit, cfg.MaxActions)
+	}
+	return report, nil
+}
+
+func (r *SimulationReport) runFight(th *TurnHandler, fighters [2]*Combatant, limit combat.RoundLimit, maxActions int) {
+	scheduler := combat.NewScheduler()
+	for i, f := range fighters {
+		scheduler.Add(i, combat.Speed(f.Char.GetAgility(), f.Char.GetAttackSpeed()))
+	}
+	round := 1
+	th.Pipeline().Use(combat.StageResist, limit.Modifier(func() int { return round }))
+
+	for actions := 0; actions < maxActions; actions++ {
+		for i, f := range fighters {
+			scheduler.SetSpeed(i, combat.Speed(combat.EffectiveAgility(f.Char), f.Char.GetAttackSpeed()))
+		}
+		id := scheduler.Next()
+		if next := scheduler.Round(); next != round {
+			round = next
+			switch limit.Judge(round, []icharacter.ICharacter{fighters[0].Char}, []icharacter.ICharacter{fighters[1].Char}) {
+			case combat.VerdictSideA:
+				r.finish(0, limit.Max)
+				return
+			case combat.VerdictSideB:
+				r.finish(1, limit.Max)
+				return
+			case combat.VerdictDraw:
+				r.finish(-1, limit.Max)
+				return
+			}
+			if !limit.SuddenDeath(round) {
+				for i, f := range fighters {
+					hp, _ := f.Char.Regenerate()
+					r.Sides[i].Regen += hp
+				}
+			}
+		}
+		attacker, defender := fighters[id], fighters[1-id]
+		if !combat.EffectsOf(attacker.Char).Stunned {
+			part := attacker.Char.Hit()
+			if attacker.AI != nil {
+				part = attacker.AI.ChooseAttackPart(attacker.Char)
+			}
+			r.Sides[id].record(th.StrikeAt(attacker.Char, defender.Char, part))
+		}
+		attacker.Char.TickInjuries()
+
+		for i, f := range fighters {
+			if !f.IsAlive() {
+				r.finish(1-i, round)
+				return
+			}
+		}
+	}
+	r.finish(-1, round)
+}
+
+func (r *SimulationReport) finish(winner, rounds int) {
+	if winner < 0 {
+		r.Draws++
+	} else {
+		r.Sides[winner].Wins++
+	}
+	r.TotalRounds += rounds
+}
+
+func (s *SideStats) record(res combat.DamageResult) {
+	s.Attacks++
+	switch {
+	case res.Blocked:
+		s.Blocked++
+	case res.Dodged:
+		s.Dodged++
+	case res.SelfHit:
+		s.SelfHits++
+	default:
+		s.Hits++
+		s.Damage = append(s.Damage, res.Damage)
+		s.TotalDmg += res.Damage
+		s.Lifesteal += res.Healed
+		if res.Critical {
+			s.Crits++
+		}
+	}
+}
+
+func (r *SimulationReport) AverageRounds() float64 {
+	if r.Config.Fights == 0 {
+		return 0
+	}
+	return float64(r.TotalRounds) / float64(r.Config.Fights)
+}
+
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
+
+func percentile(sorted []int, p float64) int {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+var simulationHeader = []string{
+	"боец", "победы", "победы_%", "ничьи_%", "раунды_ср", "атаки",
+	"урон_ср", "урон_мин", "урон_p50", "урон_p90", "урон_макс",
+	"крит_%", "уклон_%", "блок_%", "по_себе", "вампиризм", "регенерация",
+}
+
+func (r *SimulationReport) rows() [][]string {
+	rows := make([][]string, 0, 2)
+	for _, s := range r.Sides {
+		if s == nil {
+			continue
+		}
+		sorted := append([]int(nil), s.Damage...)
+		sort.Ints(sorted)
+		mean := 0.0
+		if s.Hits > 0 {
+			mean = float64(s.TotalDmg) / float64(s.Hits)
+		}
+		minDmg, maxDmg := 0, 0
+		if len(sorted) > 0 {
+			minDmg, maxDmg = sorted[0], sorted[len(sorted)-1]
+		}
+		rows = append(rows, []string{
+			s.Name,
+			strconv.Itoa(s.Wins),
+			fmt.Sprintf("%.1f", percent(s.Wins, r.Config.Fights)),
+			fmt.Sprintf("%.1f", percent(r.Draws, r.Config.Fights)),
+			fmt.Sprintf("%.2f", r.AverageRounds()),
+			strconv.Itoa(s.Attacks),
+			fmt.Sprintf("%.1f", mean),
+			strconv.Itoa(minDmg),
+			strconv.Itoa(percentile(sorted, 0.5)),
+			strconv.Itoa(percentile(sorted, 0.9)),
+			strconv.Itoa(maxDmg),
+			fmt.Sprintf("%.1f", percent(s.Crits, s.Hits)),
+			fmt.Sprintf("%.1f", percent(s.Dodged, s.Attacks)),
+			fmt.Sprintf("%.1f", percent(s.Blocked, s.Attacks)),
+			strconv.Itoa(s.SelfHits),
+			strconv.Itoa(s.Lifesteal),
+			strconv.Itoa(s.Regen),
+		})
+	}
+	return rows
+}
+
+func (r *SimulationReport) WriteTable(w io.Writer) error {
+	fmt.Fprintf(w, "Боёв: %d, сид: %d, сложность: %s, средняя длительность: %.2f раунда\n\n",
+		r.Config.Fights, r.Config.Seed, r.Config.Difficulty.Name, r.AverageRounds())
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(simulationHeader, "\t"))
+	for _, row := range r.rows() {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (r *SimulationReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(simulationHeader); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(r.rows()); err != nil {
+		return err
+	}
+	return cw.Error()
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"MyGame/Struct/Character"
+	"MyGame/Struct/Equipment"
+	"MyGame/Struct/Item"
 )
 
 const (
@@ -80,6 +82,23 @@ var (
 			Italic(true)
 )
 
+var rarityColors = map[Item.Rarity]string{
+	Item.Common:    ColorNormal,
+	Item.Uncommon:  ColorSuccess,
+	Item.Rare:      "33",
+	Item.Epic:      "129",
+	Item.Legendary: "208",
+	Item.Mythic:    ColorDanger,
+}
+
+func RarityStyle(rarity Item.Rarity) lipgloss.Style {
+	color, ok := rarityColors[rarity]
+	if !ok {
+		color = ColorNormal
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+}
+
 func RenderMenuItem(selected bool, text string) string {
 	prefix := "  "
 	if selected {
@@ -88,23 +107,20 @@ func RenderMenuItem(selected bool, text string) string {
 	return MenuItemSelected.Render(prefix + text)
 }
 
-func centerText(text string, width int) string {
-	r := []rune(text)
-	if len(r) >= width {
-		return string(r[:width])
-	}
-	pad := (width - len(r)) / 2
-	return strings.Repeat(" ", pad) + text + strings.Repeat(" ", width-len(r)-pad)
-}
+const MinCharacterBoxWidth = 30
 
 func RenderCharacterBox(char *Character.Character, label string, width int) string {
-	var b strings.Builder
-	if width < MinWidth {
-		width = MinWidth
+	if width < MinCharacterBoxWidth {
+		width = MinCharacterBoxWidth
+	}
+	innerWidth := width - 4
+	maxHP := char.GetMaxHP()
+	if maxHP <= 0 {
+		maxHP = 1
 	}
-	hpPercent := float64(char.GetHP()) / float64(char.GetMaxHP())
-	hpBarWidth := 35
-	filled := int(float64(hpBarWidth) * hpPercent)
+	hpPercent := float64(char.GetHP()) / float64(maxHP)
+	hpBarWidth := max(innerWidth-12, 6)
+	filled := min(int(float64(hpBarWidth)*hpPercent), hpBarWidth)
 	empty := hpBarWidth - filled
 	var hpBarColor string
 	if hpPercent > 0.6 {
@@ -117,20 +133,82 @@ func RenderCharacterBox(char *Character.Character, label string, width int) stri
 	hpBar := lipgloss.NewStyle().Foreground(lipgloss.Color(hpBarColor)).Render(strings.Repeat("█", filled)) + strings.Repeat("░", empty)
 	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorTitle)).Bold(true)
 	statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorStats))
-	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorBorder))
-	boxWidth := width - 4
-	nameText := centerText(nameStyle.Render(fmt.Sprintf("%s: %s", label, char.GetName())), boxWidth-2)
-	hpText := fmt.Sprintf("HP: [%s] %d%%", hpBar, int(hpPercent*100))
-	statsText := fmt.Sprintf("❤️  %d/%d  │  ⚔️  %.1f  │  🛡️  %.1f", char.GetHP(), char.GetMaxHP(), char.GetAttack(), char.GetDefense())
-	info := fmt.Sprintf("╔%s╗\n║%s║\n╠%s╣\n║ %s ║\n║ %s ║\n╚%s╝",
-		borderStyle.Render(strings.Repeat("═", boxWidth)), nameText, borderStyle.Render(strings.Repeat("═", boxWidth)),
-		centerText(hpText, boxWidth-2), centerText(statsStyle.Render(statsText), boxWidth-2), borderStyle.Render(strings.Repeat("═", boxWidth)))
-	for _, line := range strings.Split(info, "\n") {
-		pad := (width - len([]rune(line))) / 2
-		if pad < 0 {
-			pad = 0
+	borderColor := ColorBorder
+	if !char.IsAlive() {
+		borderColor = ColorHelp
+	}
+
+	name := char.GetName()
+	if !char.IsAlive() {
+		name = "☠ " + name
+	}
+	lines := []string{
+		nameStyle.Render(fmt.Sprintf("%s: %s (ур. %d)", label, name, char.GetLevel())),
+		fmt.Sprintf("[%s] %d%%", hpBar, int(hpPercent*100)),
+		statsStyle.Render(fmt.Sprintf("❤️ %d/%d │ ⚔️ %.1f │ 🛡️ %.1f", char.GetHP(), char.GetMaxHP(), char.GetAttack(), char.GetDefense())),
+	}
+	lines = append(lines, RenderBodyDiagram(char)...)
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(lipgloss.Color(borderColor)).
+		Width(innerWidth + 2).
+		Align(lipgloss.Center).
+		Render(strings.Join(lines, "\n"))
+}
+
+func RenderBodyDiagram(char *Character.Character) []string {
+	injured := make(map[string]bool)
+	names := make([]string, 0)
+	for _, injury := range char.GetInjuries() {
+		injured[injury.Zone] = true
+		names = append(names, fmt.Sprintf("%s %d", injury.Name(), injury.Turns))
+	}
+	bodyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorNormal))
+	hurtStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDanger)).Bold(true)
+	part := func(zone, glyph string) string {
+		if injured[zone] {
+			return hurtStyle.Render(glyph)
 		}
-		b.WriteString(strings.Repeat(" ", pad) + line + "\n")
+		return bodyStyle.Render(glyph)
+	}
+	status := HelpStyle.Render("Травм нет")
+	if len(names) > 0 {
+		status = WarningStyle.Render("🩹 " + strings.Join(names, ", "))
+	}
+	figure := strings.Join([]string{
+		" " + part("Голова", "O") + " ",
+		part("Правая рука", "/") + part("Тело", "|") + part("Левая рука", "\\"),
+		part("Правая нога", "/") + " " + part("Левая нога", "\\"),
+	}, "\n")
+	return []string{
+		lipgloss.JoinHorizontal(lipgloss.Top, figure, "   ", RenderArmorCoverage(char)),
+		status,
+	}
+}
+
+func RenderArmorCoverage(char *Character.Character) string {
+	armor := make(map[string]string, len(Equipment.ArmorZones))
+	for _, zone := range Equipment.ArmorZones {
+		armor[zone.Name] = fmt.Sprintf("%s %.0f", zone.Name, char.GetZoneDefense(zone.Parts[0]))
+	}
+	armorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorStats))
+	return armorStyle.Render(strings.Join([]string{
+		"🛡️ " + armor["Голова"],
+		armor["Тело"] + " · " + armor["Руки"],
+		armor["Ноги"],
+	}, "\n"))
+}
+
+func RenderPanelsRow(panels []string, width int) string {
+	if len(panels) == 0 {
+		return ""
+	}
+	row := lipgloss.JoinHorizontal(lipgloss.Top, panels...)
+	rowWidth := lipgloss.Width(row)
+	var b strings.Builder
+	for _, line := range strings.Split(row, "\n") {
+		CenteredLineByVisibleWidth(&b, line, rowWidth, width)
 	}
 	return b.String()
 }
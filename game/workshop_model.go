@@ -0,0 +1,230 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"MyGame/Struct/Item"
+	"MyGame/Struct/Workshop"
+	"MyGame/core"
+	"MyGame/game/ui"
+)
+
+type workshopTab int
+
+const (
+	workshopTabUpgrade workshopTab = iota
+	workshopTabCombine
+	workshopTabRecipes
+)
+
+var workshopTabNames = []string{"Улучшение", "Слияние", "Рецепты"}
+
+type WorkshopModel struct {
+	gameManager *core.ExtendedGameManager
+	tab         workshopTab
+	selected    int
+	message     string
+	Width       int
+	Height      int
+}
+
+func NewWorkshopModel(gameManager *core.ExtendedGameManager) *WorkshopModel {
+	return &WorkshopModel{
+		gameManager: gameManager,
+		Width:       ui.MinWidth,
+		Height:      ui.MinHeight,
+	}
+}
+
+func (m *WorkshopModel) slots() []int {
+	player := m.gameManager.GetPlayer()
+	if player == nil {
+		return nil
+	}
+	out := make([]int, 0)
+	for i, item := range player.GetInventory().GetItems() {
+		if Workshop.Upgradable(item) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+func (m *WorkshopModel) count() int {
+	if m.tab == workshopTabRecipes {
+		return len(m.gameManager.Workshop.Recipes())
+	}
+	return len(m.slots())
+}
+
+func (m *WorkshopModel) Update(msg tea.Msg) (*WorkshopModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "left", "h":
+		m.tab = (m.tab + workshopTab(len(workshopTabNames)) - 1) % workshopTab(len(workshopTabNames))
+		m.selected = 0
+	case "right", "l", "tab":
+		m.tab = (m.tab + 1) % workshopTab(len(workshopTabNames))
+		m.selected = 0
+	case "up", "k":
+		if m.selected > 0 {
+			m.selected--
+		}
+	case "down", "j":
+		if m.selected < m.count()-1 {
+			m.selected++
+		}
+	case "enter", " ":
+		m.work()
+	case "q", "й":
+		return m, func() tea.Msg { return ViewChangeMsg{View: ViewMainMenu} }
+	}
+	return m, nil
+}
+
+func (m *WorkshopModel) work() {
+	player := m.gameManager.GetPlayer()
+	if player == nil || m.selected >= m.count() {
+		return
+	}
+	switch m.tab {
+	case workshopTabRecipes:
+		item, err := Workshop.Craft(player, m.gameManager.Workshop.Recipes()[m.selected])
+		if err == nil {
+			err = m.gameManager.GiveItem(item)
+		}
+		if err != nil {
+			m.message = fmt.Sprintf("❌ %v", err)
+			return
+		}
+		m.message = fmt.Sprintf("🔨 Создано: %s", item.GetFullName())
+		m.gameManager.AutoSave()
+		return
+	case workshopTabCombine:
+		item, err := Workshop.Combine(player, m.slots()[m.selected])
+		if err != nil {
+			m.message = fmt.Sprintf("❌ %v", err)
+			return
+		}
+		m.message = fmt.Sprintf("✨ Получено: %s (ур. %d)", item.GetFullName(), item.Level)
+	default:
+		item, err := Workshop.Upgrade(player, m.slots()[m.selected])
+		if err != nil {
+			m.message = fmt.Sprintf("❌ %v", err)
+			return
+		}
+		m.message = fmt.Sprintf("⬆️ %s теперь %d уровня", item.GetFullName(), item.Level)
+	}
+	m.gameManager.AutoSave()
+	m.selected = min(m.selected, max(m.count()-1, 0))
+}
+
+func renderCost(cost Workshop.Cost) string {
+	parts := []string{fmt.Sprintf("%d зол.", cost.Gold)}
+	for _, ing := range cost.Materials {
+		parts = append(parts, fmt.Sprintf("%s ×%d", Workshop.TemplateName(ing.TemplateID), ing.Count))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (m *WorkshopModel) View() string {
+	var b strings.Builder
+	width := max(m.Width, ui.MinWidth)
+
+	for i := 0; i < max(0, (m.Height-30)/3); i++ {
+		b.WriteString("\n")
+	}
+
+	titleStyle := ui.TitleStyle.Copy().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(ui.ColorBorder)).Padding(0, 1)
+	ui.CenteredLineBuilder(&b, titleStyle.Render("🔨 МАСТЕРСКАЯ"), width)
+	b.WriteString("\n")
+
+	player := m.gameManager.GetPlayer()
+	if player == nil {
+		return b.String()
+	}
+	statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ui.ColorStats))
+	ui.CenteredLineBuilder(&b, statsStyle.Render(fmt.Sprintf("💰 Золото: %d  │  🧱 %s: %d  │  ✨ %s: %d",
+		player.Gold,
+		Workshop.TemplateName(Item.IronIngotID), Workshop.Count(player, Item.IronIngotID),
+		Workshop.TemplateName(Item.ArcaneDustID), Workshop.Count(player, Item.ArcaneDustID))), width)
+	b.WriteString("\n")
+
+	tabs := make([]string, len(workshopTabNames))
+	for i, name := range workshopTabNames {
+		if workshopTab(i) == m.tab {
+			tabs[i] = ui.SelectedStyle.Render("[ " + name + " ]")
+		} else {
+			tabs[i] = ui.NormalStyle.Render("  " + name + "  ")
+		}
+	}
+	ui.CenteredLineBuilder(&b, strings.Join(tabs, " "), width)
+	b.WriteString("\n")
+
+	var (
+		cost   Workshop.Cost
+		detail string
+	)
+	if m.tab == workshopTabRecipes {
+		for i, recipe := range m.gameManager.Workshop.Recipes() {
+			ui.CenteredLineBuilder(&b, ui.RenderMenuItem(i == m.selected, ui.RarityStyle(recipe.Result.Rarity).Render(recipe.Name)), width)
+			if i == m.selected {
+				cost = recipe.Cost()
+				if item, err := Item.CreateItem(recipe.Result.TemplateID, recipe.Result.Rarity, recipe.Result.Level); err == nil {
+					detail = itemDetail(player, item)
+				}
+			}
+		}
+	} else {
+		items := player.GetInventory().GetItems()
+		for i, idx := range m.slots() {
+			item := items[idx]
+			line := fmt.Sprintf("%s (ур. %d)", item.GetFullName(), item.Level)
+			if m.tab == workshopTabCombine {
+				line += fmt.Sprintf(" — копий: %d/%d", len(Workshop.Duplicates(player, idx))+1, Workshop.CombineCount)
+			}
+			ui.CenteredLineBuilder(&b, ui.RenderMenuItem(i == m.selected, ui.RarityStyle(item.Rarity).Render(line)), width)
+			if i == m.selected {
+				detail = itemDetail(player, item)
+				cost = Workshop.UpgradeCost(item)
+				if m.tab == workshopTabCombine {
+					cost = Workshop.CombineCost(item)
+				}
+			}
+		}
+	}
+
+	if m.count() == 0 {
+		empty := "Нечего обрабатывать: в инвентаре нет снаряжения"
+		if m.tab == workshopTabRecipes {
+			empty = "Рецептов нет"
+		}
+		ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(empty), width)
+	} else {
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, statsStyle.Render("Стоимость: "+renderCost(cost)), width)
+		if missing := Workshop.Missing(player, cost); len(missing) > 0 {
+			ui.CenteredLineBuilder(&b, ui.WarningStyle.Render("Не хватает: "+strings.Join(missing, ", ")), width)
+		}
+		b.WriteString("\n")
+		for _, line := range strings.Split(detail, "\n") {
+			ui.CenteredLineBuilder(&b, ui.NormalStyle.Render(line), width)
+		}
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		ui.CenteredLineBuilder(&b, ui.WarningStyle.Render(m.message), width)
+	}
+
+	b.WriteString("\n")
+	ui.CenteredLineBuilder(&b, ui.HelpStyle.Render("←→ Раздел  │  ↑↓ Выбор  │  Enter Выполнить  │  Q Выход"), width)
+	return b.String()
+}
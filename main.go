@@ -16,6 +16,14 @@ import (
 func main() {
 	_ = godotenv.Load()
 
+	if len(os.Args) > 1 && os.Args[1] == "simulate" {
+		if err := runSimulate(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Ошибка симуляции: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
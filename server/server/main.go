@@ -9,6 +9,9 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+
+	"MyGame/combat"
+	"MyGame/config"
 )
 
 const (
@@ -127,7 +130,10 @@ func runPvPMatch(conn1, conn2 net.Conn) {
 	defer conn1.Close()
 	defer conn2.Close()
 
-	initLine := "INIT p1name=Игрок1 p1hp=100 p1max=100 p2name=Игрок2 p2hp=100 p2max=100 round=1 turn=1"
+	cfg := config.Load()
+	limit := combat.NewRoundLimit(cfg.BattleRounds, cfg.RoundRule(config.ModePvP))
+	initLine := fmt.Sprintf("INIT p1name=Игрок1 p1hp=%d p1max=%d p2name=Игрок2 p2hp=%d p2max=%d round=1 turn=0 rounds=%d rule=%s",
+		config.PvPHP, config.PvPHP, config.PvPHP, config.PvPHP, limit.Max, limit.Rule)
 
 	if _, err := io.WriteString(conn1, "YOU_ARE 1\n"); err != nil {
 		return
@@ -143,8 +149,10 @@ func runPvPMatch(conn1, conn2 net.Conn) {
 		return
 	}
 
+	resolver := newPvPResolver(conn1, conn2, limit)
+
 	var wg sync.WaitGroup
-	relay := func(from, to net.Conn) {
+	relay := func(side int, from, to net.Conn) {
 		defer wg.Done()
 		rd := bufio.NewReaderSize(from, 4096)
 		for {
@@ -156,6 +164,14 @@ func runPvPMatch(conn1, conn2 net.Conn) {
 			if line == "" {
 				continue
 			}
+			switch {
+			case strings.HasPrefix(line, "STATS"):
+				resolver.handleStats(side, line)
+				continue
+			case strings.HasPrefix(line, "STATE"):
+				resolver.handleState(line)
+				continue
+			}
 			if _, err := io.WriteString(to, line+"\n"); err != nil {
 				return
 			}
@@ -163,7 +179,121 @@ func runPvPMatch(conn1, conn2 net.Conn) {
 		}
 	}
 	wg.Add(2)
-	go relay(conn1, conn2)
-	go relay(conn2, conn1)
+	go relay(1, conn1, conn2)
+	go relay(2, conn2, conn1)
 	wg.Wait()
 }
+
+const pvpOrderPreview = 4
+
+type pvpResolver struct {
+	mu        sync.Mutex
+	conns     [2]net.Conn
+	scheduler *combat.Scheduler
+	joined    map[int]bool
+	started   bool
+	finished  bool
+	p1hp      int
+	p2hp      int
+	maxHP     [2]int
+	limit     combat.RoundLimit
+}
+
+func newPvPResolver(conn1, conn2 net.Conn, limit combat.RoundLimit) *pvpResolver {
+	return &pvpResolver{
+		conns:     [2]net.Conn{conn1, conn2},
+		scheduler: combat.NewScheduler(),
+		joined:    make(map[int]bool),
+		p1hp:      config.PvPHP,
+		p2hp:      config.PvPHP,
+		maxHP:     [2]int{config.PvPHP, config.PvPHP},
+		limit:     limit,
+	}
+}
+
+func (r *pvpResolver) handleStats(side int, line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	agility, _ := strconv.Atoi(lineField(line, "agility"))
+	speed, err := strconv.ParseFloat(lineField(line, "speed"), 32)
+	if err != nil {
+		speed = 1
+	}
+	if maxHP, err := strconv.Atoi(lineField(line, "maxhp")); err == nil && maxHP > 0 {
+		r.maxHP[side-1] = maxHP
+	}
+	r.scheduler.Add(side, combat.Speed(agility, float32(speed)))
+	r.joined[side] = true
+	fmt.Printf("[PvP] Игрок %d: ловкость=%d, скорость атаки=%.2f, HP=%d\n", side, agility, speed, r.maxHP[side-1])
+
+	if !r.started && r.joined[1] && r.joined[2] {
+		r.started = true
+		r.broadcastTurn()
+	}
+}
+
+func (r *pvpResolver) handleState(line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if v, err := strconv.Atoi(lineField(line, "p1hp")); err == nil {
+		r.p1hp = v
+	}
+	if v, err := strconv.Atoi(lineField(line, "p2hp")); err == nil {
+		r.p2hp = v
+	}
+	if !r.started || r.finished {
+		return
+	}
+	r.broadcastTurn()
+}
+
+func (r *pvpResolver) broadcastTurn() {
+	turn := r.scheduler.Next()
+	if r.judgeRoundLimit() {
+		return
+	}
+	order := make([]string, 0, pvpOrderPreview)
+	for _, side := range r.scheduler.Peek(pvpOrderPreview) {
+		order = append(order, strconv.Itoa(side))
+	}
+	line := fmt.Sprintf("STATE round=%d p1hp=%d p2hp=%d turn=%d order=%s",
+		r.scheduler.Round(), r.p1hp, r.p2hp, turn, strings.Join(order, ","))
+	for _, conn := range r.conns {
+		_, _ = io.WriteString(conn, line+"\n")
+	}
+	fmt.Printf("[PvP] %s\n", line)
+}
+
+func (r *pvpResolver) judgeRoundLimit() bool {
+	round := r.scheduler.Round()
+	verdict := r.limit.JudgeShares(round, float64(r.p1hp)/float64(r.maxHP[0]), float64(r.p2hp)/float64(r.maxHP[1]))
+	var line string
+	switch verdict {
+	case combat.VerdictNone:
+		return false
+	case combat.VerdictSideA:
+		line = "END winner=1 reason=hp"
+	case combat.VerdictSideB:
+		line = "END winner=2 reason=hp"
+	default:
+		line = "END winner=0 draw=1 reason=rounds"
+	}
+	r.finished = true
+	for _, conn := range r.conns {
+		_, _ = io.WriteString(conn, line+"\n")
+	}
+	fmt.Printf("[PvP] Лимит в %d раундов исчерпан на раунде %d: %s\n", r.limit.Max, round, line)
+	return true
+}
+
+func lineField(line, key string) string {
+	prefix := key + "="
+	for _, part := range strings.Fields(line) {
+		if strings.HasPrefix(part, prefix) {
+			return part[len(prefix):]
+		}
+	}
+	return ""
+}
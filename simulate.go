@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"MyGame/Struct/Bestiary"
+	"MyGame/config"
+	"MyGame/game"
+)
+
+func runSimulate(args []string) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	a := fs.String("a", "warrior", "боец A: warrior, mage, rogue или id из бестиария")
+	b := fs.String("b", "dragon", "боец B: warrior, mage, rogue или id из бестиария")
+	loadoutA := fs.String("loadout-a", "", "ID предметов для бойца A через запятую")
+	loadoutB := fs.String("loadout-b", "", "ID предметов для бойца B через запятую")
+	fights := fs.Int("n", 1000, "количество боёв")
+	seed := fs.Int64("seed", 1, "начальный сид; бой i использует сид seed+i")
+	maxActions := fs.Int("max-actions", 0, "лимит действий в одном бою (0 — по умолчанию)")
+	format := fs.String("format", "table", "формат отчёта: table или csv")
+	difficulty := fs.String("difficulty", string(config.DifficultyNormal), "сложность для противников из бестиария: easy, normal, hard или nightmare")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	specA, err := game.ParseFighterSpec(*a, *loadoutA)
+	if err != nil {
+		return err
+	}
+	specB, err := game.ParseFighterSpec(*b, *loadoutB)
+	if err != nil {
+		return err
+	}
+
+	if _, err := config.ParseDifficulty(*difficulty); err != nil {
+		return err
+	}
+
+	bestiary, err := Bestiary.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️ %v\n", err)
+	}
+
+	report, err := game.RunSimulation(game.SimulationConfig{
+		A:          specA,
+		B:          specB,
+		Fights:     *fights,
+		Seed:       *seed,
+		MaxActions: *maxActions,
+		Difficulty: config.DifficultyFor(*difficulty),
+		Game:       config.Load(),
+	}, bestiary)
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "csv":
+		return report.WriteCSV(os.Stdout)
+	case "table":
+		return report.WriteTable(os.Stdout)
+	default:
+		return fmt.Errorf("неизвестный формат отчёта '%s'", *format)
+	}
+}
@@ -90,6 +90,9 @@ func (l *Logger) BattleEvent(round int, attacker, defender, action string, damag
 		l.Info("БОЙ [раунд %d]: %s %s %s", round, attacker, action, defender)
 	}
 }
+func (l *Logger) DamageEvent(round int, summary string) {
+	l.Info("УРОН [раунд %d]: %s", round, summary)
+}
 func (l *Logger) InventoryEvent(action, itemName string, success bool) {
 	status := "успешно"
 	if !success {